@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/flarelang/flare/pkg/checker"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// checkCmd represents the check command
+var checkCmd = &cobra.Command{
+	Use:   "check <file.fl|folder>",
+	Short: "Check the type annotations of Flare (.fl) files without running them",
+	Run:   execCheck,
+}
+
+func init() {
+	// Add the check command to the root command
+	rootCmd.AddCommand(checkCmd)
+	checkCmd.Flags().BoolP("nocolor", "n", false, "Enable or disable colorized output")
+}
+
+// execCheck executes the check command
+func execCheck(cmd *cobra.Command, args []string) {
+	if len(args) == 0 {
+		_ = cmd.Help()
+		return
+	}
+
+	colors := cmd.Flag("nocolor").Value.String() == "false"
+
+	if !colors {
+		color.NoColor = true
+	}
+
+	if len(args) > 1 {
+		cmd.PrintErrln("Only one file or directory can be checked at a time")
+		return
+	}
+
+	issues, err := checker.New().CheckPath(args[0])
+	if err != nil {
+		cmd.PrintErrln("Error: " + err.Error())
+		os.Exit(1)
+	}
+
+	if len(issues) == 0 {
+		cmd.Println("No issues found")
+		return
+	}
+
+	for _, issue := range issues {
+		printRunError(cmd, issue)
+	}
+	cmd.PrintErrf("Found %d issue(s)\n", len(issues))
+	os.Exit(1)
+}
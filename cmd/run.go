@@ -5,9 +5,12 @@ import (
 	"errors"
 	"io"
 	"os"
+	"path/filepath"
 
 	"github.com/flarelang/flare/internal/errs"
+	"github.com/flarelang/flare/internal/race"
 	"github.com/flarelang/flare/pkg/language"
+	"github.com/flarelang/flare/pkg/pkgman"
 	"github.com/flarelang/flare/pkg/prettycode"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
@@ -27,6 +30,7 @@ func init() {
 	runCmd.Flags().BoolP("debug", "d", false, "Run the program in debug mode")
 	runCmd.Flags().BoolP("cache", "c", false, "Allow or disallow caching")
 	runCmd.Flags().BoolP("nocolor", "n", false, "Enable or disable colorized output")
+	runCmd.Flags().Bool("race", false, "Report variables accessed by multiple threads without synchronization")
 }
 
 // execRun executes the run command
@@ -39,6 +43,7 @@ func execRun(cmd *cobra.Command, args []string) {
 	colors := cmd.Flag("nocolor").Value.String() == "false"
 	debug := cmd.Flag("debug").Value.String() == "true"
 	caching := cmd.Flag("cache").Value.String() == "true"
+	raceMode := cmd.Flag("race").Value.String() == "true"
 
 	if !colors {
 		color.NoColor = true
@@ -73,28 +78,94 @@ func execRun(cmd *cobra.Command, args []string) {
 	}
 	defer file.Close()
 
-	interpreter := language.NewInterpreter(mode, caching)
+	interpreter := language.NewInterpreter(mode, caching).WithSearchPaths(searchPaths(cmd, args[0])...)
 
-	if _, err = interpreter.Interpret(args[0], file); err != nil {
-		var de errs.DebugError
+	var detector *race.Detector
+	if raceMode {
+		detector = race.New()
+		interpreter.WithRaceDetector(detector)
+	}
 
-		if errors.As(err, &de) {
-			s := de.PrettyError(func(r io.Reader) string {
-				b, _ := io.ReadAll(r)
+	_, err = interpreter.Interpret(args[0], file)
 
-				pc, err := prettycode.New(bytes.NewReader(b))
-				if err != nil {
-					return string(b)
-				}
+	if detector != nil {
+		printRaceReports(cmd, detector.Reports())
+	}
 
-				return pc.HighlightConsole()
-			})
+	if err != nil {
+		printRunError(cmd, err)
+		return
+	}
+}
 
-			cmd.PrintErrln(errors.New(s))
-			return
+// searchPaths returns the paths of the flare.yaml in the directory of the script or in one of its parents,
+// a package file that cannot be read is reported and ignored, so it does not stop the script
+func searchPaths(cmd *cobra.Command, script string) []string {
+	dir, err := filepath.Abs(filepath.Dir(script))
+	if err != nil {
+		return nil
+	}
+
+	for {
+		if _, err := os.Stat(filepath.Join(dir, pkgman.PkgFile)); err == nil {
+			pm, err := pkgman.New(dir)
+			if err != nil {
+				cmd.PrintErrf("Ignoring %s: %s\n", filepath.Join(dir, pkgman.PkgFile), err)
+				return nil
+			}
+			return pm.SearchPaths()
 		}
 
-		cmd.PrintErrln(err)
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil
+		}
+		dir = parent
+	}
+}
+
+// printRaceReports prints the races found by the race detector
+func printRaceReports(cmd *cobra.Command, reports []race.Report) {
+	if len(reports) == 0 {
 		return
 	}
+
+	for _, report := range reports {
+		cmd.PrintErrln(color.New(color.FgRed, color.Bold).Sprint("=================="))
+		cmd.PrintErrln(report)
+	}
+	cmd.PrintErrln(color.New(color.FgRed, color.Bold).Sprint("=================="))
+	cmd.PrintErrf("Found %d data race(s)\n", len(reports))
+}
+
+// printRunError prints the error with debug information,
+// joined errors (like the errors of deferred expressions) are printed one by one
+func printRunError(cmd *cobra.Command, err error) {
+	var je errs.JoinedError
+	if errors.As(err, &je) {
+		for _, err := range je.Errors() {
+			printRunError(cmd, err)
+		}
+		return
+	}
+
+	var de errs.DebugError
+
+	if errors.As(err, &de) {
+		s := de.PrettyError(func(r io.Reader) string {
+			b, _ := io.ReadAll(r)
+
+			pc, err := prettycode.New(bytes.NewReader(b))
+			if err != nil {
+				return string(b)
+			}
+
+			return pc.HighlightConsole()
+		})
+
+		cmd.PrintErrln(errors.New(s))
+		return
+	}
+
+	cmd.PrintErrln(err)
 }
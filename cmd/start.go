@@ -66,7 +66,7 @@ func execStart(cmd *cobra.Command, args []string) {
 		}
 		defer file.Close()
 
-		interpreter := language.NewInterpreter(mode, true)
+		interpreter := language.NewInterpreter(mode, true).WithSearchPaths(pm.SearchPaths()...)
 
 		if _, err = interpreter.Interpret(entry, file); err != nil {
 			cmd.PrintErrln(err)
@@ -95,7 +95,7 @@ func execStart(cmd *cobra.Command, args []string) {
 			}
 		}
 
-		interpreter := language.NewInterpreter(mode, true)
+		interpreter := language.NewInterpreter(mode, true).WithSearchPaths(pm.SearchPaths()...)
 		httpServer := server.New(interpreter, entry, info.IsDir(), true, colors, dev)
 
 		if err := httpServer.Serve(listenAddr); err != nil && err != http.ErrServerClosed {
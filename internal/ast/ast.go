@@ -67,7 +67,7 @@ func (b *Builder) buildNode(ts []*models.Token, inx *int) (*models.Node, error)
 	switch token.Type {
 	case tokens.Namespace:
 		return nil, errs.WithDebug(fmt.Errorf("%w: namespace can only be at the beginning of the file", errs.SyntaxError), token.Debug)
-	case tokens.Addition, tokens.Subtraction, tokens.Multiplication, tokens.Division, tokens.Equation, tokens.NotEquation, tokens.Greater, tokens.GreaterOrEqual, tokens.Less, tokens.LessOrEqual, tokens.And, tokens.Or, tokens.Not, tokens.Power, tokens.Increment, tokens.Decrement:
+	case tokens.Addition, tokens.Subtraction, tokens.Multiplication, tokens.Division, tokens.Equation, tokens.NotEquation, tokens.Greater, tokens.GreaterOrEqual, tokens.Less, tokens.LessOrEqual, tokens.And, tokens.Or, tokens.Not, tokens.Power, tokens.Increment, tokens.Decrement, tokens.Pipe, tokens.Question, tokens.Colon, tokens.Coalesce:
 		*inx++
 		return &models.Node{
 			Type:    token.Type,
@@ -78,14 +78,22 @@ func (b *Builder) buildNode(ts []*models.Token, inx *int) (*models.Node, error)
 		return b.parseInlineValue(ts, inx)
 	case tokens.Let, tokens.Const:
 		return b.parseLetConst(ts, inx)
+	case tokens.Interface:
+		return b.parseInterface(ts, inx)
+	case tokens.Enum:
+		return b.parseEnum(ts, inx)
 	case tokens.Define:
 		return b.parseDefine(ts, inx)
 	case tokens.Function:
 		return b.parseFunction(ts, inx)
+	case tokens.At:
+		return b.parseDecorators(ts, inx)
 	case tokens.Identifier, tokens.This:
 		return b.parseIdentifier(ts, inx)
 	case tokens.Return:
 		return b.parseReturn(ts, inx)
+	case tokens.Yield:
+		return b.parseYield(ts, inx)
 	case tokens.If:
 		return b.parseIf(ts, inx)
 	case tokens.Use:
@@ -102,6 +110,16 @@ func (b *Builder) buildNode(ts []*models.Token, inx *int) (*models.Node, error)
 		return b.parseFor(ts, inx)
 	case tokens.Error:
 		return b.parseError(ts, inx)
+	case tokens.Try:
+		return b.parseTry(ts, inx)
+	case tokens.Throw:
+		return b.parseThrow(ts, inx)
+	case tokens.Defer:
+		return b.parseDefer(ts, inx)
+	case tokens.Break, tokens.Continue:
+		return b.parseLoopControl(ts, inx)
+	case tokens.Match:
+		return b.parseMatch(ts, inx)
 	case tokens.Semicolon:
 		*inx++
 		return nil, nil
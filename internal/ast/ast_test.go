@@ -84,3 +84,340 @@ func Test_Define(t *testing.T) {
 	assert.Equal(t, "this.id", body.Content, "fn must assign to this.id")
 	assert.Equal(t, "id", body.Value, "fn must assign id")
 }
+
+func Test_LoopControl(t *testing.T) {
+	nodes := build(t, `
+		while true {
+			if x: continue;
+			break;
+		}
+	`)
+
+	assert.Equal(t, 1, len(nodes), "must create one while node")
+	loop := nodes[0]
+
+	assert.Equal(t, 2, len(loop.Children), "while body must have 2 children")
+	assert.Equal(t, tokens.Continue, loop.Children[0].Children[0].Children[0].Type, "if body must be a continue")
+	assert.Equal(t, tokens.Break, loop.Children[1].Type, "second statement must be a break")
+}
+
+func Test_Match(t *testing.T) {
+	nodes := build(t, `
+		let x = match y {
+			0 => "zero",
+			[first, ...rest] if first > 1 => first,
+			n: int => { return n; }
+			else => nil,
+		};
+	`)
+
+	assert.Equal(t, 1, len(nodes), "must create one let node")
+	match := nodes[0].Children[0]
+
+	assert.Equal(t, tokens.Match, match.Type, "value must be a match")
+	assert.Equal(t, 4, len(match.Children), "match must have 4 arms")
+
+	list := match.Children[1]
+	assert.Equal(t, tokens.List, list.Args[0].Type, "second arm must be a list pattern")
+	assert.Equal(t, []string{"rest"}, list.Args[0].Children[1].Flags, "last list element must be a rest pattern")
+	assert.Equal(t, 2, len(list.Args), "second arm must have a guard")
+
+	typed := match.Children[2]
+	assert.Equal(t, "int", typed.Args[0].Value, "third arm must be typed")
+	assert.Equal(t, []string{"block"}, typed.Flags, "third arm must be a block")
+
+	assert.Equal(t, tokens.Else, match.Children[3].Args[0].Type, "last arm must be the default")
+}
+
+func Test_FnParams(t *testing.T) {
+	nodes := build(t, `
+		fn f(a, b = 10, ...rest) {}
+		f(1, b: 2);
+	`)
+
+	assert.Equal(t, 2, len(nodes), "must create a function and a call")
+
+	fn := nodes[0]
+	assert.Equal(t, 3, len(fn.Args), "function must have 3 args")
+	assert.Equal(t, []string{"default"}, fn.Args[1].Flags, "b must have a default value")
+	assert.Equal(t, "rest", fn.Args[2].Content, "last arg must be rest")
+	assert.Equal(t, []string{"variadic"}, fn.Args[2].Flags, "rest must be variadic")
+
+	call := nodes[1]
+	assert.Equal(t, 2, len(call.Args), "call must have 2 args")
+	assert.Equal(t, "b", call.Args[1].Content, "second arg must be named b")
+	assert.Equal(t, []string{"named"}, call.Args[1].Flags, "second arg must be named")
+}
+
+func Test_Destructure(t *testing.T) {
+	nodes := build(t, `
+		let [a, ...rest] = [1, 2, 3];
+		for {key, value} in items {}
+	`)
+
+	assert.Equal(t, 2, len(nodes), "must create a let and a for node")
+
+	let := nodes[0]
+	assert.Equal(t, []string{"destructure"}, let.Flags, "let must destructure")
+	assert.Equal(t, tokens.List, let.Args[0].Type, "let pattern must be a list")
+	assert.Equal(t, tokens.ListVariable, let.VariableType, "let value must be a list")
+
+	loop := nodes[1]
+	assert.Equal(t, []string{"destructure"}, loop.Flags, "for must destructure")
+	assert.Equal(t, tokens.Array, loop.Args[0].Type, "for pattern must be an array")
+	assert.Equal(t, "value", loop.Args[0].Children[1].Content, "second key must be value")
+}
+
+func Test_Inheritance(t *testing.T) {
+	nodes := build(t, `
+		interface Named {
+			let name;
+			fn greet(greeting);
+		}
+
+		define Admin extends app.User implements Named {}
+	`)
+
+	assert.Equal(t, 2, len(nodes), "must create an interface and a definition")
+
+	iface := nodes[0]
+	assert.Equal(t, tokens.Interface, iface.Type, "first node must be an interface")
+	assert.Equal(t, 2, len(iface.Children), "interface must have 2 members")
+	assert.Equal(t, "greet", iface.Children[1].Content, "second member must be greet")
+	assert.Equal(t, 1, len(iface.Children[1].Args), "greet must have 1 argument")
+
+	def := nodes[1]
+	assert.Equal(t, "app.User", def.Map["extends"], "definition must extend app.User")
+	assert.Equal(t, []string{"Named"}, def.Map["implements"], "definition must implement Named")
+}
+
+func Test_IndexAssign(t *testing.T) {
+	nodes := build(t, `
+		items[i][0] = 5;
+		total = items[0];
+	`)
+
+	assert.Equal(t, 2, len(nodes), "must create 2 assignments")
+
+	assign := nodes[0]
+	assert.Equal(t, tokens.Assign, assign.Type, "first node must be an assignment")
+	assert.True(t, assign.HasFlag("index"), "first node must be an index assignment")
+	assert.Equal(t, 2, len(assign.Args), "assignment must have 2 accessors")
+	assert.Equal(t, 0, len(assign.ObjectAccessors), "accessors must not apply to the value")
+
+	assert.False(t, nodes[1].HasFlag("index"), "second node must be a plain assignment")
+}
+
+func Test_ForArrayLiteral(t *testing.T) {
+	nodes := build(t, `
+		for entry in array{a: 1} {
+			println(entry);
+		}
+	`)
+
+	assert.Equal(t, 1, len(nodes), "must create a for node")
+	assert.Equal(t, 2, len(nodes[0].Args), "for must have an item and an iterable")
+	assert.Equal(t, 1, len(nodes[0].Children), "array literal braces must not start the body")
+}
+
+func Test_Generator(t *testing.T) {
+	nodes := build(t, `
+		fn* numbers(max) {
+			yield max;
+			yield max * 2;
+		}
+	`)
+
+	assert.Equal(t, 1, len(nodes), "must create a function")
+
+	fn := nodes[0]
+	assert.True(t, fn.HasFlag("generator"), "function must be a generator")
+	assert.Equal(t, "numbers", fn.Content, "function must be named numbers")
+	assert.Equal(t, 2, len(fn.Children), "generator must have 2 yields")
+	assert.Equal(t, tokens.Yield, fn.Children[1].Type, "second statement must be a yield")
+}
+
+func Test_TryCatch(t *testing.T) {
+	nodes := build(t, `
+		try {
+			throw NotFound("missing");
+		} catch (e: NotFound) {
+			println(e);
+		} catch {
+			println("other");
+		} finally {
+			println("done");
+		}
+	`)
+
+	assert.Equal(t, 1, len(nodes), "must create a try statement")
+
+	try := nodes[0]
+	assert.Equal(t, tokens.Try, try.Type, "statement must be a try")
+	assert.Equal(t, tokens.Throw, try.Children[0].Type, "try body must contain the throw")
+	assert.Equal(t, 3, len(try.Args), "try must have 2 catch clauses and a finally")
+	assert.Equal(t, "e", try.Args[0].Content, "first catch must bind e")
+	assert.Equal(t, "NotFound", try.Args[0].Value, "first catch must match NotFound")
+	assert.Equal(t, "", try.Args[1].Content, "second catch must not bind the error")
+	assert.Equal(t, tokens.Finally, try.Args[2].Type, "last clause must be finally")
+}
+
+func Test_Defer(t *testing.T) {
+	nodes := build(t, `
+		fn read(file) {
+			defer file.close();
+			return file.readLine();
+		}
+	`)
+
+	assert.Equal(t, 1, len(nodes), "must create a function")
+
+	stmt := nodes[0].Children[0]
+	assert.Equal(t, tokens.Defer, stmt.Type, "first statement must be a defer")
+	assert.Equal(t, tokens.ExpressionVariable, stmt.VariableType, "defer must hold an expression")
+}
+
+func Test_Spin(t *testing.T) {
+	nodes := build(t, `
+		let results = spin (limit: n + 1) x in items {
+			return x;
+		};
+	`)
+
+	assert.Equal(t, 1, len(nodes), "must create one let node")
+
+	spin := nodes[0].Children[0]
+	assert.Equal(t, tokens.Spin, spin.Type, "value must be a spin")
+	assert.Equal(t, tokens.SpinVariable, spin.VariableType, "spin must be usable as a value")
+	assert.Equal(t, 3, len(spin.Args), "spin must have an identifier, an iterable and a limit")
+	assert.True(t, spin.HasFlag("limit"), "spin must have a limit")
+	assert.Equal(t, 1, len(spin.Children), "spin body must have 1 child")
+}
+
+func Test_UseSelective(t *testing.T) {
+	nodes := build(t, `
+		namespace app.main;
+		use app.models.user;
+		use (find, create as createUser) from app.models.user;
+	`)
+
+	assert.Equal(t, 3, len(nodes), "must create a namespace and two use nodes")
+	assert.Equal(t, "app.main", nodes[0].Content, "namespace must be dotted")
+
+	use := nodes[1]
+	assert.Equal(t, "app.models.user", use.Content, "use must name the dotted namespace")
+	assert.Equal(t, "user", use.Value, "dotted namespace must be used by its last part")
+
+	selective := nodes[2]
+	assert.Equal(t, "app.models.user", selective.Content, "selective use must name the namespace")
+	assert.Equal(t, 2, len(selective.Args), "selective use must have 2 members")
+	assert.Equal(t, "find", selective.Args[0].Value, "member without alias must keep its name")
+	assert.Equal(t, "create", selective.Args[1].Content, "member must keep the imported name")
+	assert.Equal(t, "createUser", selective.Args[1].Value, "member must be used by its alias")
+}
+
+func Test_TypeAnnotations(t *testing.T) {
+	nodes := build(t, `
+		fn add(a: int, b: int = 1): int {
+			return a + b;
+		}
+		let name: models.User = nil;
+	`)
+
+	assert.Equal(t, 2, len(nodes), "must create a function and a let node")
+
+	fn := nodes[0]
+	assert.Equal(t, "int", fn.Map["returns"], "function must have a return type")
+	assert.Equal(t, 2, len(fn.Args), "function must have 2 arguments")
+	assert.Equal(t, "int", fn.Args[0].Map["type"], "first argument must be typed")
+	assert.Equal(t, "int", fn.Args[1].Map["type"], "second argument must be typed")
+	assert.True(t, fn.Args[1].HasFlag("default"), "second argument must keep its default value")
+
+	assert.Equal(t, "models.User", nodes[1].Map["type"], "variable must have a dotted type")
+}
+
+func Test_Enum(t *testing.T) {
+	nodes := build(t, `
+		enum Status {
+			Active = "active",
+			Deleted,
+		}
+	`)
+
+	assert.Equal(t, 1, len(nodes), "must create one enum node")
+	assert.Equal(t, tokens.Enum, nodes[0].Type, "node must be an enum")
+	assert.Equal(t, "Status", nodes[0].Content, "enum must have a name")
+	assert.Equal(t, 2, len(nodes[0].Args), "enum must have 2 values, the trailing comma is allowed")
+	assert.Equal(t, "active", nodes[0].Args[0].Value, "first value must have an associated value")
+	assert.Equal(t, tokens.NilVariable, nodes[0].Args[1].VariableType, "second value must not have an associated value")
+}
+
+func Test_PipeAndComprehension(t *testing.T) {
+	nodes := build(t, `
+		let names = users |> filter(isActive) |> map(toName);
+		let doubled = [x * 2 for x in items if x > 0];
+	`)
+
+	assert.Equal(t, 2, len(nodes), "must create 2 let nodes")
+
+	pipe := nodes[0].Children
+	assert.Equal(t, 5, len(pipe), "pipe must have 3 steps and 2 operators")
+	assert.Equal(t, tokens.Pipe, pipe[1].Type, "steps must be separated by pipes")
+	assert.Equal(t, "map", pipe[4].Content, "last step must be the map call")
+
+	assert.Equal(t, 1, len(nodes[1].Children), "comprehension must be a single expression")
+	comprehension := nodes[1].Children[0]
+	assert.Equal(t, tokens.ComprehensionVariable, comprehension.VariableType, "node must be a comprehension")
+	assert.Equal(t, "x", comprehension.Args[0].Content, "comprehension must have a loop variable")
+	assert.Equal(t, 2, len(comprehension.Children), "comprehension must have a value and a condition")
+}
+
+func Test_NilSafeAndConditional(t *testing.T) {
+	nodes := build(t, `
+		let city = user?.address?.city ?? "unknown";
+		let label = age >= 18 ? "adult" : "minor";
+		count += 1;
+		scores[0] ??= 5;
+	`)
+
+	assert.Equal(t, 4, len(nodes), "must create 4 nodes")
+
+	coalesce := nodes[0].Children
+	assert.Equal(t, 3, len(coalesce), "coalescing must have 2 values and an operator")
+	assert.Equal(t, "user?.address?.city", coalesce[0].Content, "nil-safe members must keep their question mark")
+	assert.Equal(t, tokens.Coalesce, coalesce[1].Type, "values must be separated by '??'")
+
+	conditional := nodes[1].Children
+	assert.Equal(t, 7, len(conditional), "conditional must have a condition and 2 values")
+	assert.Equal(t, tokens.Question, conditional[3].Type, "condition must be followed by '?'")
+	assert.Equal(t, tokens.Colon, conditional[5].Type, "values must be separated by ':'")
+
+	assert.Equal(t, tokens.Assign, nodes[2].Type, "compound assignment must be an assignment")
+	assert.Equal(t, "+=", nodes[2].Map["operator"], "compound assignment must keep its operator")
+
+	assert.True(t, nodes[3].HasFlag("index"), "compound assignment must support indexes")
+	assert.Equal(t, "??=", nodes[3].Map["operator"], "compound assignment must keep its operator")
+}
+
+func Test_Decorators(t *testing.T) {
+	nodes := build(t, `
+		@memoize
+		@route("GET", "/users")
+		fn users() {}
+
+		@tagged
+		define User {}
+	`)
+
+	assert.Equal(t, 2, len(nodes), "must create a function and a definition")
+	assert.Equal(t, tokens.Function, nodes[0].Type, "decorated node must be the function")
+
+	decorators := nodes[0].Map["decorators"].([]*models.Node)
+	assert.Equal(t, 2, len(decorators), "function must have 2 decorators")
+	assert.Equal(t, "memoize", decorators[0].Content, "decorators must keep their order")
+	assert.Equal(t, tokens.FunctionCallVariable, decorators[1].VariableType, "decorator with arguments must be a call")
+	assert.Equal(t, 2, len(decorators[1].Args), "decorator call must have 2 arguments")
+
+	assert.Equal(t, tokens.Define, nodes[1].Type, "decorated node must be the definition")
+	assert.Equal(t, 1, len(nodes[1].Map["decorators"].([]*models.Node)), "definition must have a decorator")
+}
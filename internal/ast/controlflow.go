@@ -292,13 +292,48 @@ func (b *Builder) parseFor(ts []*models.Token, inx *int) (*models.Node, error) {
 		return nil, errs.WithDebug(fmt.Errorf("%w: expected iterable expression, but got 'EOF'", errs.SyntaxError), token.Debug)
 	}
 
-	if ts[*inx].Type != tokens.Identifier {
-		return nil, errs.WithDebug(fmt.Errorf("%w: expected identifier, but got '%s'", errs.SyntaxError, ts[*inx].Type), ts[*inx].Debug)
+	var limit *models.Node
+	if token.Type == tokens.Spin {
+		// spin can be used as an expression, it returns the results of the iterations
+		node.VariableType = tokens.SpinVariable
+
+		if ts[*inx].Type == tokens.LeftParenthesis {
+			var err error
+			limit, err = b.parseSpinOptions(ts, inx)
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
 
-	iteratorVariable := ts[*inx]
+	var (
+		iterator []*models.Node
+		err      error
+	)
 
-	*inx++
+	switch ts[*inx].Type {
+	case tokens.Identifier:
+		iterator, err = b.Build([]*models.Token{ts[*inx], SemiColonToken})
+		if err != nil {
+			return nil, err
+		}
+		*inx++
+	case tokens.LeftBracket, tokens.LeftBrace:
+		pattern, err := b.collectPattern(ts, inx)
+		if err != nil {
+			return nil, err
+		}
+
+		patternNode, err := b.parsePattern(pattern, pattern[0].Debug)
+		if err != nil {
+			return nil, err
+		}
+
+		iterator = []*models.Node{patternNode}
+		node.Flags = append(node.Flags, "destructure")
+	default:
+		return nil, errs.WithDebug(fmt.Errorf("%w: expected identifier or pattern, but got '%s'", errs.SyntaxError, ts[*inx].Type), ts[*inx].Debug)
+	}
 
 	if *inx >= len(ts) {
 		return nil, errs.WithDebug(fmt.Errorf("%w: expected 'in', but got 'EOF'", errs.SyntaxError), token.Debug)
@@ -310,12 +345,22 @@ func (b *Builder) parseFor(ts []*models.Token, inx *int) (*models.Node, error) {
 
 	*inx++
 
-	var args []*models.Token
+	var (
+		args  []*models.Token
+		depth int
+	)
 	for {
-		if ts[*inx].Type == tokens.LeftBrace {
+		if *inx >= len(ts) {
+			return nil, errs.WithDebug(fmt.Errorf("%w: expected '{', but got 'EOF'", errs.SyntaxError), token.Debug)
+		}
+
+		// braces inside parentheses or brackets belong to the iterable, e.g. array literals
+		isArray := len(args) > 0 && args[len(args)-1].Type == tokens.Array
+		if ts[*inx].Type == tokens.LeftBrace && depth == 0 && !isArray {
 			break
 		}
 
+		depth += b.depthChange(ts[*inx])
 		args = append(args, ts[*inx])
 		*inx++
 	}
@@ -324,20 +369,25 @@ func (b *Builder) parseFor(ts []*models.Token, inx *int) (*models.Node, error) {
 		return nil, errs.WithDebug(fmt.Errorf("%w: expected value or expression, but got EOF", errs.SyntaxError), token.Debug)
 	}
 
-	iterator, err := b.Build([]*models.Token{iteratorVariable, SemiColonToken})
+	iterable, err := b.parseExpressionTokens(args, tokens.In, "iterable")
 	if err != nil {
 		return nil, err
 	}
 
-	args = append(args, SemiColonToken)
-	bArgs, err := b.Build(args)
-	if err != nil {
-		return nil, err
+	// a single value is used as it is, expressions like `items |> filter(isActive)` are evaluated first
+	bArgs := []*models.Node{iterable}
+	if len(iterable.Children) == 1 {
+		bArgs = iterable.Children
 	}
 
 	node.Args = append(node.Args, iterator...)
 	node.Args = append(node.Args, bArgs...)
 
+	if limit != nil {
+		node.Args = append(node.Args, limit)
+		node.Flags = append(node.Flags, "limit")
+	}
+
 	*inx++
 
 	var (
@@ -379,3 +429,293 @@ func (b *Builder) parseFor(ts []*models.Token, inx *int) (*models.Node, error) {
 
 	return node, nil
 }
+
+func (b *Builder) parseLoopControl(ts []*models.Token, inx *int) (*models.Node, error) {
+	token := ts[*inx]
+	node := &models.Node{
+		Type:    token.Type,
+		Content: token.Type.String(),
+		Debug:   token.Debug,
+	}
+
+	*inx++
+
+	if *inx >= len(ts) {
+		return node, nil
+	}
+
+	if ts[*inx].Type != tokens.Semicolon {
+		return nil, errs.WithDebug(fmt.Errorf("%w: expected ';' after '%s', but got '%s'", errs.SyntaxError, token.Value, ts[*inx].Type), ts[*inx].Debug)
+	}
+
+	*inx++
+	return node, nil
+}
+
+// parseSpinOptions parses the `(limit: expr)` options of a spin loop and returns the limit expression
+func (b *Builder) parseSpinOptions(ts []*models.Token, inx *int) (*models.Node, error) {
+	start := ts[*inx]
+	*inx++
+
+	var (
+		options []*models.Token
+		depth   int
+	)
+	for {
+		if *inx >= len(ts) {
+			return nil, errs.WithDebug(fmt.Errorf("%w: expected ')', but got 'EOF'", errs.SyntaxError), start.Debug)
+		}
+
+		if ts[*inx].Type == tokens.RightParenthesis && depth == 0 {
+			*inx++
+			break
+		}
+
+		depth += b.depthChange(ts[*inx])
+		options = append(options, ts[*inx])
+		*inx++
+	}
+
+	if len(options) < 3 || options[0].Type != tokens.Identifier || options[0].Value != "limit" || options[1].Type != tokens.Colon {
+		return nil, errs.WithDebug(fmt.Errorf("%w: expected spin options like '(limit: 8)'", errs.SyntaxError), start.Debug)
+	}
+
+	children, err := b.Build(append(options[2:], SemiColonToken))
+	if err != nil {
+		return nil, err
+	}
+
+	if *inx >= len(ts) {
+		return nil, errs.WithDebug(fmt.Errorf("%w: expected identifier or pattern, but got 'EOF'", errs.SyntaxError), start.Debug)
+	}
+
+	return &models.Node{
+		Type:         options[0].Type,
+		VariableType: tokens.ExpressionVariable,
+		Content:      "limit",
+		Children:     children,
+		Debug:        options[2].Debug,
+	}, nil
+}
+
+func (b *Builder) parseMatch(ts []*models.Token, inx *int) (*models.Node, error) {
+	token := ts[*inx]
+	node := &models.Node{
+		Type:         tokens.Match,
+		VariableType: tokens.MatchVariable,
+		Content:      "match",
+		Debug:        token.Debug,
+	}
+
+	*inx++
+
+	var (
+		subject []*models.Token
+		depth   int
+	)
+
+	for {
+		if *inx >= len(ts) {
+			return nil, errs.WithDebug(fmt.Errorf("%w: expected '{', but got 'EOF'", errs.SyntaxError), token.Debug)
+		}
+
+		if depth == 0 && ts[*inx].Type == tokens.LeftBrace {
+			break
+		}
+
+		depth += b.depthChange(ts[*inx])
+		subject = append(subject, ts[*inx])
+		*inx++
+	}
+
+	if len(subject) == 0 {
+		return nil, errs.WithDebug(fmt.Errorf("%w: expected value or expression, but got '{'", errs.SyntaxError), token.Debug)
+	}
+
+	bArgs, err := b.Build(append(subject, SemiColonToken))
+	if err != nil {
+		return nil, err
+	}
+	node.Args = bArgs
+
+	*inx++
+
+	var (
+		body       []*models.Token
+		braceCount = 1
+	)
+
+	for {
+		if *inx >= len(ts) {
+			return nil, errs.WithDebug(fmt.Errorf("%w: expected '}', but got 'EOF'", errs.SyntaxError), token.Debug)
+		}
+
+		if ts[*inx].Type == tokens.RightBrace {
+			braceCount--
+			if braceCount == 0 {
+				*inx++
+				break
+			}
+		}
+
+		if ts[*inx].Type == tokens.LeftBrace {
+			braceCount++
+		}
+
+		body = append(body, ts[*inx])
+		*inx++
+	}
+
+	var pos int
+	for pos < len(body) {
+		arm, err := b.parseMatchArm(body, &pos)
+		if err != nil {
+			return nil, err
+		}
+		node.Children = append(node.Children, arm)
+	}
+
+	if *inx < len(ts) && ts[*inx].Type == tokens.Semicolon {
+		*inx++
+	}
+
+	return node, nil
+}
+
+// parseMatchArm parses a single `pattern [if guard] => value` arm of a match
+func (b *Builder) parseMatchArm(ts []*models.Token, inx *int) (*models.Node, error) {
+	token := ts[*inx]
+	node := &models.Node{
+		Type:    tokens.MatchArm,
+		Content: "arm",
+		Debug:   token.Debug,
+	}
+
+	var (
+		pattern []*models.Token
+		guard   []*models.Token
+		isGuard bool
+		depth   int
+	)
+
+	for {
+		if *inx >= len(ts) {
+			return nil, errs.WithDebug(fmt.Errorf("%w: expected '=>', but got 'EOF'", errs.SyntaxError), token.Debug)
+		}
+
+		current := ts[*inx]
+		if depth == 0 && current.Type == tokens.Arrow {
+			*inx++
+			break
+		}
+
+		if depth == 0 && current.Type == tokens.If && !isGuard {
+			isGuard = true
+			*inx++
+			continue
+		}
+
+		depth += b.depthChange(current)
+		if isGuard {
+			guard = append(guard, current)
+		} else {
+			pattern = append(pattern, current)
+		}
+		*inx++
+	}
+
+	patternNode, err := b.parsePattern(pattern, token.Debug)
+	if err != nil {
+		return nil, err
+	}
+	node.Args = append(node.Args, patternNode)
+
+	if isGuard {
+		if len(guard) == 0 {
+			return nil, errs.WithDebug(fmt.Errorf("%w: expected guard expression after 'if'", errs.SyntaxError), token.Debug)
+		}
+
+		guardNodes, err := b.Build(append(guard, SemiColonToken))
+		if err != nil {
+			return nil, err
+		}
+
+		node.Args = append(node.Args, &models.Node{
+			Type:         tokens.If,
+			VariableType: tokens.ExpressionVariable,
+			Content:      "guard",
+			Children:     guardNodes,
+			Debug:        guard[0].Debug,
+		})
+	}
+
+	if *inx >= len(ts) {
+		return nil, errs.WithDebug(fmt.Errorf("%w: expected value or block after '=>', but got 'EOF'", errs.SyntaxError), token.Debug)
+	}
+
+	var value []*models.Token
+
+	if ts[*inx].Type == tokens.LeftBrace {
+		node.Flags = append(node.Flags, "block")
+		braceCount := 1
+		*inx++
+
+		for {
+			if *inx >= len(ts) {
+				return nil, errs.WithDebug(fmt.Errorf("%w: expected '}', but got 'EOF'", errs.SyntaxError), token.Debug)
+			}
+
+			if ts[*inx].Type == tokens.RightBrace {
+				braceCount--
+				if braceCount == 0 {
+					*inx++
+					break
+				}
+			}
+
+			if ts[*inx].Type == tokens.LeftBrace {
+				braceCount++
+			}
+
+			value = append(value, ts[*inx])
+			*inx++
+		}
+
+		if *inx < len(ts) && ts[*inx].Type == tokens.Comma {
+			*inx++
+		}
+
+		children, err := b.Build(value)
+		if err != nil {
+			return nil, err
+		}
+
+		node.Children = children
+		return node, nil
+	}
+
+	depth = 0
+	for *inx < len(ts) {
+		if depth == 0 && ts[*inx].Type == tokens.Comma {
+			*inx++
+			break
+		}
+
+		depth += b.depthChange(ts[*inx])
+		value = append(value, ts[*inx])
+		*inx++
+	}
+
+	if len(value) == 0 {
+		return nil, errs.WithDebug(fmt.Errorf("%w: expected value or block after '=>'", errs.SyntaxError), token.Debug)
+	}
+
+	children, err := b.Build(append(value, SemiColonToken))
+	if err != nil {
+		return nil, err
+	}
+
+	node.VariableType = tokens.ExpressionVariable
+	node.Children = children
+	return node, nil
+}
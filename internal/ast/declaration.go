@@ -18,6 +18,10 @@ func (b *Builder) parseLetConst(ts []*models.Token, inx *int) (*models.Node, err
 	if *inx >= len(ts) {
 		return nil, errs.WithDebug(fmt.Errorf("%w: expected identifier, but got 'EOF'", errs.SyntaxError), token.Debug)
 	}
+	if ts[*inx].Type == tokens.LeftBracket || ts[*inx].Type == tokens.LeftBrace {
+		return b.parseDestructure(ts, inx, node)
+	}
+
 	if ts[*inx].Type != tokens.Identifier {
 		return nil, errs.WithDebug(fmt.Errorf("%w: expected identifier, but got '%s' 3", errs.SyntaxError, ts[*inx].Type), ts[*inx].Debug)
 	}
@@ -25,6 +29,16 @@ func (b *Builder) parseLetConst(ts []*models.Token, inx *int) (*models.Node, err
 	node.Content = ts[*inx].Value
 
 	*inx++
+	// the type of the variable, like `let name: string = "John";`
+	if *inx < len(ts) && ts[*inx].Type == tokens.Colon {
+		*inx++
+		typ, err := b.parseType(ts, inx, token)
+		if err != nil {
+			return nil, err
+		}
+		node.Map = map[string]any{"type": typ}
+	}
+
 	if *inx >= len(ts) {
 		return nil, errs.WithDebug(fmt.Errorf("%w: expected assignment operator, but got 'EOF'", errs.SyntaxError), token.Debug)
 	}
@@ -79,27 +93,95 @@ func (b *Builder) parseLetConst(ts []*models.Token, inx *int) (*models.Node, err
 		return nil, errs.WithDebug(fmt.Errorf("%w: expected value or expression, but got '%s'", errs.SyntaxError, token.Type), token.Debug)
 	}
 
+	if err := b.parseDeclarationValue(node, values); err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
+// parseDeclarationValue sets the value of a let or const node from the value tokens
+func (b *Builder) parseDeclarationValue(node *models.Node, values []*models.Token) error {
 	if len(values) == 1 {
 		node.Value = b.getValue(values[0])
 		typ, err := b.getType(values[0])
 		if err != nil {
-			return nil, err
+			return err
 		}
 		node.VariableType = typ
+		return nil
+	}
+
+	values = append(values, SemiColonToken)
+	children, err := b.Build(values)
+	if err != nil {
+		return err
+	}
+
+	if len(children) == 1 && children[0].Type == tokens.List {
+		node.VariableType = tokens.ListVariable
+		node.Children = children[0].Children
 	} else {
-		values = append(values, SemiColonToken)
-		children, err := b.Build(values)
-		if err != nil {
-			return nil, err
+		node.Children = children
+		node.VariableType = tokens.ExpressionVariable
+	}
+
+	return nil
+}
+
+// parseDestructure parses destructuring declarations like `let [a, b] = list;` or `let {name} = arr;`
+func (b *Builder) parseDestructure(ts []*models.Token, inx *int, node *models.Node) (*models.Node, error) {
+	token := ts[*inx]
+
+	pattern, err := b.collectPattern(ts, inx)
+	if err != nil {
+		return nil, err
+	}
+
+	patternNode, err := b.parsePattern(pattern, token.Debug)
+	if err != nil {
+		return nil, err
+	}
+
+	node.Args = []*models.Node{patternNode}
+	node.Flags = append(node.Flags, "destructure")
+
+	if *inx >= len(ts) || ts[*inx].Type != tokens.Assign {
+		return nil, errs.WithDebug(fmt.Errorf("%w: expected assignment operator after pattern", errs.SyntaxError), token.Debug)
+	}
+
+	var (
+		values     []*models.Token
+		braceCount int
+	)
+	for {
+		*inx++
+		if *inx >= len(ts) {
+			return nil, errs.WithDebug(fmt.Errorf("%w: expected value or expression, but got 'EOF'", errs.SyntaxError), token.Debug)
 		}
 
-		if len(children) == 1 && children[0].Type == tokens.List {
-			node.VariableType = tokens.ListVariable
-			node.Children = children[0].Children
-		} else {
-			node.Children = children
-			node.VariableType = tokens.ExpressionVariable
+		if ts[*inx].Type == tokens.LeftBrace {
+			braceCount++
+		}
+
+		if ts[*inx].Type == tokens.RightBrace {
+			braceCount--
+		}
+
+		if ts[*inx].Type == tokens.Semicolon && braceCount == 0 {
+			*inx++
+			break
 		}
+
+		values = append(values, ts[*inx])
+	}
+
+	if len(values) == 0 {
+		return nil, errs.WithDebug(fmt.Errorf("%w: expected value or expression after '='", errs.SyntaxError), token.Debug)
+	}
+
+	if err := b.parseDeclarationValue(node, values); err != nil {
+		return nil, err
 	}
 
 	return node, nil
@@ -124,6 +206,33 @@ func (b *Builder) parseDefine(ts []*models.Token, inx *int) (*models.Node, error
 	node.Content = ts[*inx].Value
 	*inx++
 
+	if *inx < len(ts) && ts[*inx].Type == tokens.Extends {
+		*inx++
+		parent, err := b.parseNameList(ts, inx, token)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(parent) != 1 {
+			return nil, errs.WithDebug(fmt.Errorf("%w: a definition can only extend one definition", errs.SyntaxError), token.Debug)
+		}
+
+		node.Map = map[string]any{"extends": parent[0]}
+	}
+
+	if *inx < len(ts) && ts[*inx].Type == tokens.Implements {
+		*inx++
+		interfaces, err := b.parseNameList(ts, inx, token)
+		if err != nil {
+			return nil, err
+		}
+
+		if node.Map == nil {
+			node.Map = map[string]any{}
+		}
+		node.Map["implements"] = interfaces
+	}
+
 	if *inx >= len(ts) {
 		return nil, errs.WithDebug(fmt.Errorf("%w: expected '{', but got 'EOF'", errs.SyntaxError), token.Debug)
 	}
@@ -173,6 +282,213 @@ func (b *Builder) parseDefine(ts []*models.Token, inx *int) (*models.Node, error
 	return node, nil
 }
 
+// parseNameList parses a comma separated list of dotted names up to the next '{'
+func (b *Builder) parseNameList(ts []*models.Token, inx *int, token *models.Token) ([]string, error) {
+	var (
+		names   []string
+		current []*models.Token
+	)
+
+	for {
+		if *inx >= len(ts) {
+			return nil, errs.WithDebug(fmt.Errorf("%w: expected '{', but got 'EOF'", errs.SyntaxError), token.Debug)
+		}
+
+		t := ts[*inx]
+		if t.Type == tokens.Comma || t.Type == tokens.LeftBrace || t.Type == tokens.Implements {
+			if len(current) == 0 {
+				return nil, errs.WithDebug(fmt.Errorf("%w: expected identifier, but got '%s'", errs.SyntaxError, t.Value), t.Debug)
+			}
+
+			name, err := b.parseDottedName(current)
+			if err != nil {
+				return nil, err
+			}
+			names = append(names, name)
+			current = nil
+
+			if t.Type != tokens.Comma {
+				return names, nil
+			}
+
+			*inx++
+			continue
+		}
+
+		current = append(current, t)
+		*inx++
+	}
+}
+
+// parseInterface parses an interface declaration.
+// The body lists the required methods like `fn name(a, b);` and properties like `let name;`.
+func (b *Builder) parseInterface(ts []*models.Token, inx *int) (*models.Node, error) {
+	token := ts[*inx]
+	node := &models.Node{
+		Type:  token.Type,
+		Debug: token.Debug,
+	}
+	*inx++
+
+	if *inx >= len(ts) || ts[*inx].Type != tokens.Identifier {
+		return nil, errs.WithDebug(fmt.Errorf("%w: expected interface name", errs.SyntaxError), token.Debug)
+	}
+
+	node.Content = ts[*inx].Value
+	*inx++
+
+	if *inx >= len(ts) || ts[*inx].Type != tokens.LeftBrace {
+		return nil, errs.WithDebug(fmt.Errorf("%w: expected '{' after interface name", errs.SyntaxError), token.Debug)
+	}
+
+	body, err := b.collectPattern(ts, inx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, member := range b.splitTopLevel(body[1:len(body)-1], tokens.Semicolon) {
+		if len(member) == 0 {
+			continue
+		}
+
+		if len(member) < 2 || member[1].Type != tokens.Identifier {
+			return nil, errs.WithDebug(fmt.Errorf("%w: expected 'fn name(...);' or 'let name;' in interface", errs.SyntaxError), member[0].Debug)
+		}
+
+		switch member[0].Type {
+		case tokens.Let:
+			if len(member) != 2 {
+				return nil, errs.WithDebug(fmt.Errorf("%w: interface properties cannot have a value", errs.SyntaxError), member[2].Debug)
+			}
+
+			node.Children = append(node.Children, &models.Node{
+				Type:    tokens.Let,
+				Content: member[1].Value,
+				Debug:   member[1].Debug,
+			})
+		case tokens.Function:
+			if len(member) < 4 || member[2].Type != tokens.LeftParenthesis || member[len(member)-1].Type != tokens.RightParenthesis {
+				return nil, errs.WithDebug(fmt.Errorf("%w: expected method signature like 'fn name(a, b);'", errs.SyntaxError), member[1].Debug)
+			}
+
+			args, err := b.parseParams(member[3 : len(member)-1])
+			if err != nil {
+				return nil, err
+			}
+
+			node.Children = append(node.Children, &models.Node{
+				Type:    tokens.Function,
+				Content: member[1].Value,
+				Args:    args,
+				Debug:   member[1].Debug,
+			})
+		default:
+			return nil, errs.WithDebug(fmt.Errorf("%w: unexpected '%s' in interface", errs.SyntaxError, member[0].Value), member[0].Debug)
+		}
+	}
+
+	if *inx < len(ts) && ts[*inx].Type == tokens.Semicolon {
+		*inx++
+	}
+
+	return node, nil
+}
+
+// parseType parses the type of an annotation after the colon, like `int`, `fn` or `models.User`
+func (b *Builder) parseType(ts []*models.Token, inx *int, token *models.Token) (string, error) {
+	if *inx >= len(ts) {
+		return "", errs.WithDebug(fmt.Errorf("%w: expected type, but got 'EOF'", errs.SyntaxError), token.Debug)
+	}
+
+	switch ts[*inx].Type {
+	case tokens.Identifier, tokens.Nil, tokens.Function, tokens.Array, tokens.Error:
+	default:
+		return "", errs.WithDebug(fmt.Errorf("%w: expected type, but got '%s'", errs.SyntaxError, ts[*inx].Type), ts[*inx].Debug)
+	}
+
+	typ := ts[*inx].Value
+	*inx++
+
+	for *inx+1 < len(ts) && ts[*inx].Type == tokens.Dot && ts[*inx+1].Type == tokens.Identifier {
+		typ += "." + ts[*inx+1].Value
+		*inx += 2
+	}
+
+	return typ, nil
+}
+
+// parseEnum parses enums like `enum Role { Admin, Editor, Viewer }`,
+// the values can have an associated value like `enum Status { Active = "active" }`
+func (b *Builder) parseEnum(ts []*models.Token, inx *int) (*models.Node, error) {
+	token := ts[*inx]
+	node := &models.Node{
+		Type:  token.Type,
+		Debug: token.Debug,
+	}
+	*inx++
+
+	if *inx >= len(ts) || ts[*inx].Type != tokens.Identifier {
+		return nil, errs.WithDebug(fmt.Errorf("%w: expected enum name", errs.SyntaxError), token.Debug)
+	}
+
+	node.Content = ts[*inx].Value
+	*inx++
+
+	if *inx >= len(ts) || ts[*inx].Type != tokens.LeftBrace {
+		return nil, errs.WithDebug(fmt.Errorf("%w: expected '{' after enum name", errs.SyntaxError), token.Debug)
+	}
+
+	body, err := b.collectPattern(ts, inx)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	for _, member := range b.splitTopLevel(body[1:len(body)-1], tokens.Comma) {
+		if len(member) == 0 {
+			continue
+		}
+
+		if member[0].Type != tokens.Identifier {
+			return nil, errs.WithDebug(fmt.Errorf("%w: expected enum value name, but got '%s'", errs.SyntaxError, member[0].Value), member[0].Debug)
+		}
+
+		if seen[member[0].Value] {
+			return nil, errs.WithDebug(fmt.Errorf("%w: duplicate enum value '%s'", errs.SyntaxError, member[0].Value), member[0].Debug)
+		}
+		seen[member[0].Value] = true
+
+		value := &models.Node{
+			Type:         tokens.Identifier,
+			VariableType: tokens.NilVariable,
+			Content:      member[0].Value,
+			Debug:        member[0].Debug,
+		}
+
+		if len(member) > 1 {
+			if member[1].Type != tokens.Assign || len(member) == 2 {
+				return nil, errs.WithDebug(fmt.Errorf("%w: expected 'Name' or 'Name = value' in enum", errs.SyntaxError), member[1].Debug)
+			}
+
+			if err := b.parseDeclarationValue(value, member[2:]); err != nil {
+				return nil, err
+			}
+		}
+
+		node.Args = append(node.Args, value)
+	}
+
+	if len(node.Args) == 0 {
+		return nil, errs.WithDebug(fmt.Errorf("%w: enum %s has no values", errs.SyntaxError, node.Content), token.Debug)
+	}
+
+	if *inx < len(ts) && ts[*inx].Type == tokens.Semicolon {
+		*inx++
+	}
+
+	return node, nil
+}
+
 func (b *Builder) parseIdentifier(ts []*models.Token, inx *int) (*models.Node, error) {
 	token := ts[*inx]
 	*inx++
@@ -187,16 +503,17 @@ func (b *Builder) parseIdentifier(ts []*models.Token, inx *int) (*models.Node, e
 		return nil, errs.WithDebug(fmt.Errorf("%w: unexpected end of file, got 'EOF'", errs.SyntaxError), token.Debug)
 	}
 
-	// Handle multi-part identifiers with dots (e.g., "app.core.func")
-	for *inx < len(ts) && ts[*inx].Type == tokens.Dot {
-		node.Content += "."
+	// Handle multi-part identifiers with dots (e.g., "app.core.func"),
+	// `?.` keeps its question mark so the member is accessed nil-safe
+	for *inx < len(ts) && (ts[*inx].Type == tokens.Dot || ts[*inx].Type == tokens.OptionalDot) {
+		node.Content += ts[*inx].Value
 		*inx++
 
 		if *inx >= len(ts) {
 			return nil, errs.WithDebug(fmt.Errorf("%w: expected identifier after dot, but got EOF", errs.SyntaxError), token.Debug)
 		}
 
-		if ts[*inx].Type != tokens.Identifier {
+		if !b.isWord(ts[*inx]) {
 			return nil, errs.WithDebug(fmt.Errorf("%w: expected identifier after dot, but got '%s'", errs.SyntaxError, ts[*inx].Type), ts[*inx].Debug)
 		}
 
@@ -214,7 +531,7 @@ func (b *Builder) parseIdentifier(ts []*models.Token, inx *int) (*models.Node, e
 		}, nil
 	}
 
-	if ts[*inx].Type != tokens.Assign && ts[*inx].Type != tokens.LeftParenthesis && ts[*inx].Type != tokens.LeftBracket && ts[*inx].Type != tokens.Increment && ts[*inx].Type != tokens.Decrement {
+	if !b.isAssignment(ts[*inx]) && ts[*inx].Type != tokens.LeftParenthesis && ts[*inx].Type != tokens.LeftBracket && ts[*inx].Type != tokens.Increment && ts[*inx].Type != tokens.Decrement {
 		if ts[*inx].Type == tokens.Semicolon {
 			*inx++
 		}
@@ -242,7 +559,15 @@ func (b *Builder) parseIdentifier(ts []*models.Token, inx *int) (*models.Node, e
 
 	if ts[*inx].Type == tokens.LeftBracket {
 		node.VariableType = tokens.ReferenceVariable
-		return b.parseObjectAccess(ts, inx, node)
+		accessed, err := b.parseObjectAccess(ts, inx, node)
+		if err != nil || *inx >= len(ts) || !b.isAssignment(ts[*inx]) {
+			return accessed, err
+		}
+
+		// index assignment, e.g. `list[0] = value`
+		node.Args = node.ObjectAccessors
+		node.ObjectAccessors = nil
+		node.Flags = append(node.Flags, "index")
 	}
 
 	if ts[*inx].Type == tokens.Increment || ts[*inx].Type == tokens.Decrement {
@@ -261,16 +586,32 @@ func (b *Builder) parseIdentifier(ts []*models.Token, inx *int) (*models.Node, e
 		return node, nil
 	}
 
-	if ts[*inx].Type != tokens.Assign {
+	if !b.isAssignment(ts[*inx]) {
 		return nil, errs.WithDebug(fmt.Errorf("%w: expected assignment operator or expression, but got '%s'", errs.SyntaxError, ts[*inx].Type), ts[*inx].Debug)
 	}
 
+	// compound assignments like `count += 1` keep their operator
+	if ts[*inx].Type != tokens.Assign {
+		node.Map = map[string]any{"operator": ts[*inx].Value}
+	}
+
 	node.Type = tokens.Assign
 
 	// Process assignment and value expressions
 	*inx++
-	var values []*models.Token
-	for *inx < len(ts) && ts[*inx].Type != tokens.Semicolon {
+	var (
+		values     []*models.Token
+		braceCount int
+	)
+	for *inx < len(ts) && (ts[*inx].Type != tokens.Semicolon || braceCount > 0) {
+		if ts[*inx].Type == tokens.LeftBrace {
+			braceCount++
+		}
+
+		if ts[*inx].Type == tokens.RightBrace {
+			braceCount--
+		}
+
 		values = append(values, ts[*inx])
 		*inx++
 	}
@@ -287,22 +628,18 @@ func (b *Builder) parseIdentifier(ts []*models.Token, inx *int) (*models.Node, e
 		}
 		node.VariableType = typ
 	} else if len(values) > 1 {
-		if values[0].Type == tokens.LeftBracket {
-			values = append(values, SemiColonToken)
-			children, err := b.Build(values)
-			if err != nil {
-				return nil, err
-			}
-			node.VariableType = tokens.ListVariable
-			node.Type = tokens.List
-			node.Children = children[0].Children
-			return node, nil
-		}
 		values = append(values, SemiColonToken)
 		children, err := b.Build(values)
 		if err != nil {
 			return nil, err
 		}
+
+		// list literals are assigned directly, comprehensions and piped lists are expressions
+		if len(children) == 1 && children[0].Type == tokens.List {
+			node.VariableType = tokens.ListVariable
+			node.Children = children[0].Children
+			return node, nil
+		}
 		node.VariableType = tokens.ExpressionVariable
 		node.Children = children
 	}
@@ -318,7 +655,7 @@ func (b *Builder) parseInlineValue(ts []*models.Token, inx *int) (*models.Node,
 		return nil, errs.WithDebug(fmt.Errorf("%w: expected value, but got 'EOF'", errs.SyntaxError), token.Debug)
 	}
 
-	if ts[*inx].Type == tokens.Semicolon || ts[*inx].Type == tokens.Comma || ts[*inx].Type == tokens.RightParenthesis || b.isExpression(ts[*inx]) {
+	if ts[*inx].Type == tokens.Semicolon || ts[*inx].Type == tokens.Comma || ts[*inx].Type == tokens.RightParenthesis || ts[*inx].Type == tokens.Colon || b.isExpression(ts[*inx]) {
 		return &models.Node{
 			Type:         token.Type,
 			VariableType: tokens.InlineValue,
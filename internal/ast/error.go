@@ -89,3 +89,130 @@ func (b *Builder) parseError(ts []*models.Token, inx *int) (*models.Node, error)
 	node.Children = children
 	return node, nil
 }
+
+// parseTry parses a try statement with its catch clauses and an optional finally block.
+// Catch clauses are stored in the arguments, the finally block is the last argument.
+func (b *Builder) parseTry(ts []*models.Token, inx *int) (*models.Node, error) {
+	token := ts[*inx]
+	*inx++
+
+	node := &models.Node{
+		Type:    tokens.Try,
+		Content: "try",
+		Debug:   token.Debug,
+	}
+
+	children, err := b.parseBlock(ts, inx, token)
+	if err != nil {
+		return nil, err
+	}
+	node.Children = children
+
+	for *inx < len(ts) && ts[*inx].Type == tokens.Catch {
+		clause, err := b.parseCatch(ts, inx)
+		if err != nil {
+			return nil, err
+		}
+		node.Args = append(node.Args, clause)
+	}
+
+	if *inx < len(ts) && ts[*inx].Type == tokens.Finally {
+		finally := &models.Node{
+			Type:    tokens.Finally,
+			Content: "finally",
+			Debug:   ts[*inx].Debug,
+		}
+		*inx++
+
+		children, err := b.parseBlock(ts, inx, token)
+		if err != nil {
+			return nil, err
+		}
+		finally.Children = children
+		node.Args = append(node.Args, finally)
+	}
+
+	if len(node.Args) == 0 {
+		return nil, errs.WithDebug(fmt.Errorf("%w: expected catch or finally after try", errs.SyntaxError), token.Debug)
+	}
+
+	return node, nil
+}
+
+// parseCatch parses a catch clause like `catch (e: NotFound) {}`, `catch (e) {}` or `catch {}`
+func (b *Builder) parseCatch(ts []*models.Token, inx *int) (*models.Node, error) {
+	token := ts[*inx]
+	*inx++
+
+	node := &models.Node{
+		Type:  tokens.Catch,
+		Debug: token.Debug,
+	}
+
+	if *inx < len(ts) && ts[*inx].Type == tokens.LeftParenthesis {
+		group, err := b.collectPattern(ts, inx)
+		if err != nil {
+			return nil, err
+		}
+
+		group = group[1 : len(group)-1]
+		if len(group) == 0 || group[0].Type != tokens.Identifier {
+			return nil, errs.WithDebug(fmt.Errorf("%w: expected error variable name in catch", errs.SyntaxError), token.Debug)
+		}
+		node.Content = group[0].Value
+
+		if len(group) > 1 {
+			if group[1].Type != tokens.Colon {
+				return nil, errs.WithDebug(fmt.Errorf("%w: expected ':' or ')', but got '%s'", errs.SyntaxError, group[1].Type), group[1].Debug)
+			}
+
+			typ, err := b.parseTypeName(group[2:], group[1].Debug)
+			if err != nil {
+				return nil, err
+			}
+			node.Value = typ
+		}
+	}
+
+	children, err := b.parseBlock(ts, inx, token)
+	if err != nil {
+		return nil, err
+	}
+	node.Children = children
+
+	return node, nil
+}
+
+// parseThrow parses a throw statement, it takes a value like return
+func (b *Builder) parseThrow(ts []*models.Token, inx *int) (*models.Node, error) {
+	token := ts[*inx]
+	if *inx+1 >= len(ts) || ts[*inx+1].Type == tokens.Semicolon {
+		return nil, errs.WithDebug(fmt.Errorf("%w: expected value after throw", errs.SyntaxError), token.Debug)
+	}
+
+	node, err := b.parseReturn(ts, inx)
+	if err != nil {
+		return nil, err
+	}
+
+	node.Content = "throw"
+	return node, nil
+}
+
+// parseBlock parses the statements of a `{ ... }` block
+func (b *Builder) parseBlock(ts []*models.Token, inx *int, token *models.Token) ([]*models.Node, error) {
+	if *inx >= len(ts) || ts[*inx].Type != tokens.LeftBrace {
+		got := "EOF"
+		if *inx < len(ts) {
+			got = ts[*inx].Type.String()
+		}
+		return nil, errs.WithDebug(fmt.Errorf("%w: expected '{', but got '%s'", errs.SyntaxError, got), token.Debug)
+	}
+
+	group, err := b.collectPattern(ts, inx)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Build(append(group[1:len(group)-1], SemiColonToken))
+}
@@ -2,6 +2,7 @@ package ast
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/flarelang/flare/internal/errs"
 	"github.com/flarelang/flare/internal/models"
@@ -21,12 +22,12 @@ func (b *Builder) parseNamespace(ts []*models.Token, inx *int) (*models.Node, er
 		return nil, errs.WithDebug(fmt.Errorf("%w: expected identifier, but got 'EOF'", errs.SyntaxError), token.Debug)
 	}
 
-	if ts[*inx].Type != tokens.Identifier {
-		return nil, errs.WithDebug(fmt.Errorf("%w: expected identifier, but got '%s'", errs.SyntaxError, ts[*inx].Type), ts[*inx].Debug)
+	name, err := b.parseNamespaceName(ts, inx, false)
+	if err != nil {
+		return nil, err
 	}
 
-	node.Content = ts[*inx].Value
-	*inx++
+	node.Content = name
 
 	if *inx >= len(ts) {
 		return node, nil
@@ -39,6 +40,13 @@ func (b *Builder) parseNamespace(ts []*models.Token, inx *int) (*models.Node, er
 	return node, nil
 }
 
+// parseUse parses the forms of the use statement:
+//
+//	use app.models.user;
+//	use app.models.user as users;
+//	use author:package;
+//	use 'app.models.user' as users;
+//	use (find, create as createUser) from app.models.user;
 func (b *Builder) parseUse(ts []*models.Token, inx *int) (*models.Node, error) {
 	token := ts[*inx]
 	node := &models.Node{
@@ -51,31 +59,51 @@ func (b *Builder) parseUse(ts []*models.Token, inx *int) (*models.Node, error) {
 		return nil, errs.WithDebug(fmt.Errorf("%w: expected identifier, but got 'EOF'", errs.SyntaxError), token.Debug)
 	}
 
-	if ts[*inx].Type != tokens.Identifier {
-		return nil, errs.WithDebug(fmt.Errorf("%w: expected identifier, but got '%s'", errs.SyntaxError, ts[*inx].Type), ts[*inx].Debug)
-	}
-
-	node.Content = ts[*inx].Value
-	node.Value = ts[*inx].Value
+	if ts[*inx].Type == tokens.LeftParenthesis {
+		members, err := b.parseUseMembers(ts, inx)
+		if err != nil {
+			return nil, err
+		}
 
-	*inx++
-	if *inx >= len(ts) {
-		return node, nil
-	}
+		if *inx >= len(ts) {
+			return nil, errs.WithDebug(fmt.Errorf("%w: expected 'from', but got 'EOF'", errs.SyntaxError), token.Debug)
+		}
 
-	if ts[*inx].Type == tokens.Colon {
+		if ts[*inx].Type != tokens.From {
+			return nil, errs.WithDebug(fmt.Errorf("%w: expected 'from', but got '%s'", errs.SyntaxError, ts[*inx].Type), ts[*inx].Debug)
+		}
 		*inx++
+
 		if *inx >= len(ts) {
-			return nil, errs.WithDebug(fmt.Errorf("%w: expected identifier, but got 'EOF'", errs.SyntaxError), token.Debug)
+			return nil, errs.WithDebug(fmt.Errorf("%w: expected namespace, but got 'EOF'", errs.SyntaxError), token.Debug)
 		}
 
-		if ts[*inx].Type != tokens.Identifier {
-			return nil, errs.WithDebug(fmt.Errorf("%w: expected identifier, but got '%s'", errs.SyntaxError, ts[*inx].Type), ts[*inx].Debug)
+		name, err := b.parseNamespaceName(ts, inx, true)
+		if err != nil {
+			return nil, err
 		}
 
-		node.Content += ":" + ts[*inx].Value
-		node.Value = ts[*inx].Value
-		*inx++
+		node.Content = name
+		node.Value = ""
+		node.Args = members
+
+		if *inx < len(ts) && ts[*inx].Type == tokens.Semicolon {
+			*inx++
+		}
+
+		return node, nil
+	}
+
+	name, err := b.parseNamespaceName(ts, inx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	node.Content = name
+	node.Value = namespaceAlias(name)
+
+	if *inx >= len(ts) {
+		return node, nil
 	}
 
 	if ts[*inx].Type != tokens.Semicolon && ts[*inx].Type != tokens.As {
@@ -87,10 +115,6 @@ func (b *Builder) parseUse(ts []*models.Token, inx *int) (*models.Node, error) {
 		return node, nil
 	}
 
-	if ts[*inx].Type != tokens.As {
-		return nil, errs.WithDebug(fmt.Errorf("%w: expected 'as', but got '%s'", errs.SyntaxError, ts[*inx].Type), ts[*inx].Debug)
-	}
-
 	*inx++
 
 	if *inx >= len(ts) {
@@ -116,3 +140,117 @@ func (b *Builder) parseUse(ts []*models.Token, inx *int) (*models.Node, error) {
 
 	return node, nil
 }
+
+// parseUseMembers parses the member list of a selective use, like `(find, create as createUser)`.
+// The name of the member is the content of the node, the local name is the value.
+func (b *Builder) parseUseMembers(ts []*models.Token, inx *int) ([]*models.Node, error) {
+	token := ts[*inx]
+	*inx++
+
+	var members []*models.Node
+
+	for *inx < len(ts) && ts[*inx].Type != tokens.RightParenthesis {
+		if ts[*inx].Type != tokens.Identifier {
+			return nil, errs.WithDebug(fmt.Errorf("%w: expected identifier, but got '%s'", errs.SyntaxError, ts[*inx].Type), ts[*inx].Debug)
+		}
+
+		member := &models.Node{
+			Type:    tokens.Identifier,
+			Content: ts[*inx].Value,
+			Value:   ts[*inx].Value,
+			Debug:   ts[*inx].Debug,
+		}
+		*inx++
+
+		if *inx < len(ts) && ts[*inx].Type == tokens.As {
+			*inx++
+
+			if *inx >= len(ts) {
+				return nil, errs.WithDebug(fmt.Errorf("%w: expected identifier, but got 'EOF'", errs.SyntaxError), token.Debug)
+			}
+
+			if ts[*inx].Type != tokens.Identifier {
+				return nil, errs.WithDebug(fmt.Errorf("%w: expected identifier, but got '%s'", errs.SyntaxError, ts[*inx].Type), ts[*inx].Debug)
+			}
+
+			member.Value = ts[*inx].Value
+			*inx++
+		}
+
+		members = append(members, member)
+
+		if *inx < len(ts) && ts[*inx].Type == tokens.Comma {
+			*inx++
+			continue
+		}
+
+		if *inx < len(ts) && ts[*inx].Type != tokens.RightParenthesis {
+			return nil, errs.WithDebug(fmt.Errorf("%w: expected ',' or ')', but got '%s'", errs.SyntaxError, ts[*inx].Type), ts[*inx].Debug)
+		}
+	}
+
+	if *inx >= len(ts) {
+		return nil, errs.WithDebug(fmt.Errorf("%w: expected ')', but got 'EOF'", errs.SyntaxError), token.Debug)
+	}
+	*inx++
+
+	if len(members) == 0 {
+		return nil, errs.WithDebug(fmt.Errorf("%w: expected at least one member to use", errs.SyntaxError), token.Debug)
+	}
+
+	return members, nil
+}
+
+// parseNamespaceName parses a dotted namespace name like `app.models.user`.
+// A use statement can also name a package as `author:package`, or quote the name.
+func (b *Builder) parseNamespaceName(ts []*models.Token, inx *int, use bool) (string, error) {
+	if use && ts[*inx].Type == tokens.String {
+		name := b.getValue(ts[*inx]).(string)
+		*inx++
+		return name, nil
+	}
+
+	if ts[*inx].Type != tokens.Identifier {
+		return "", errs.WithDebug(fmt.Errorf("%w: expected identifier, but got '%s'", errs.SyntaxError, ts[*inx].Type), ts[*inx].Debug)
+	}
+
+	name := ts[*inx].Value
+	*inx++
+
+	for *inx+1 < len(ts) && ts[*inx].Type == tokens.Dot {
+		if ts[*inx+1].Type != tokens.Identifier {
+			return "", errs.WithDebug(fmt.Errorf("%w: expected identifier after dot, but got '%s'", errs.SyntaxError, ts[*inx+1].Type), ts[*inx+1].Debug)
+		}
+
+		name += "." + ts[*inx+1].Value
+		*inx += 2
+	}
+
+	if !use || *inx >= len(ts) || ts[*inx].Type != tokens.Colon {
+		return name, nil
+	}
+
+	*inx++
+	if *inx >= len(ts) {
+		return "", errs.WithDebug(fmt.Errorf("%w: expected identifier, but got 'EOF'", errs.SyntaxError), ts[*inx-1].Debug)
+	}
+
+	if ts[*inx].Type != tokens.Identifier {
+		return "", errs.WithDebug(fmt.Errorf("%w: expected identifier, but got '%s'", errs.SyntaxError, ts[*inx].Type), ts[*inx].Debug)
+	}
+
+	name += ":" + ts[*inx].Value
+	*inx++
+
+	return name, nil
+}
+
+// namespaceAlias returns the name a namespace is used as by default,
+// the package of `author:package` and the last part of `app.models.user`
+func namespaceAlias(name string) string {
+	if i := strings.LastIndex(name, ":"); i >= 0 {
+		name = name[i+1:]
+	}
+
+	return name[strings.LastIndex(name, ".")+1:]
+}
@@ -18,6 +18,12 @@ func (b *Builder) parseFunction(ts []*models.Token, inx *int) (m *models.Node, e
 	}
 	*inx++
 
+	// `fn*` declares a generator function
+	if *inx < len(ts) && ts[*inx].Type == tokens.Multiplication {
+		node.Flags = append(node.Flags, "generator")
+		*inx++
+	}
+
 	if *inx >= len(ts) {
 		return nil, errs.WithDebug(fmt.Errorf("%w: expected identifier or '(', but got 'EOF'", errs.SyntaxError), token.Debug)
 	}
@@ -48,11 +54,10 @@ func (b *Builder) parseFunction(ts []*models.Token, inx *int) (m *models.Node, e
 	}
 
 	var (
-		args       []*models.Node
+		params     []*models.Token
 		parenCount = 1
 	)
 
-	expectArg := true
 	for {
 		if *inx >= len(ts) {
 			return nil, errs.WithDebug(fmt.Errorf("%w: expected ')', but got 'EOF'", errs.SyntaxError), token.Debug)
@@ -70,32 +75,25 @@ func (b *Builder) parseFunction(ts []*models.Token, inx *int) (m *models.Node, e
 			parenCount++
 		}
 
-		if ts[*inx].Type == tokens.Comma {
-			expectArg = true
-			*inx++
-			continue
-		}
-
-		if !expectArg {
-			return nil, errs.WithDebug(fmt.Errorf("%w: expected ',' between arguments", errs.SyntaxError), ts[*inx].Debug)
-		}
-
-		arg, err := b.buildNode(ts, inx)
-		if err != nil {
-			return nil, err
-		}
-		args = append(args, arg)
-		expectArg = false
+		params = append(params, ts[*inx])
+		*inx++
 	}
 
-	if len(args) > 0 && expectArg {
-		return nil, errs.WithDebug(fmt.Errorf("%w: expected token: ','", errs.SyntaxError), ts[*inx].Debug)
+	args, err := b.parseParams(params)
+	if err != nil {
+		return nil, err
 	}
 
 	node.Args = args
 
-	if parenCount != 0 {
-		return nil, errs.WithDebug(fmt.Errorf("%w: expected ')', but got '%s'", errs.SyntaxError, token.Type), token.Debug)
+	// the return type, like `fn add(a, b): int`
+	if *inx < len(ts) && ts[*inx].Type == tokens.Colon {
+		*inx++
+		typ, err := b.parseType(ts, inx, token)
+		if err != nil {
+			return nil, err
+		}
+		node.Map["returns"] = typ
 	}
 
 	if *inx >= len(ts) {
@@ -164,6 +162,83 @@ func (b *Builder) parseFunction(ts []*models.Token, inx *int) (m *models.Node, e
 	return node, nil
 }
 
+// parseParams parses the parameter list of a function declaration.
+// A parameter is either a name, a name with a default value (`b = 10`)
+// or a variadic name (`...rest`) as the last parameter.
+// Names can have a type annotation, like `a: int` or `b: int = 10`.
+func (b *Builder) parseParams(ts []*models.Token) ([]*models.Node, error) {
+	var args []*models.Node
+
+	parts := b.splitTopLevel(ts, tokens.Comma)
+	for i, part := range parts {
+		if len(part) == 0 {
+			return nil, errs.WithDebug(fmt.Errorf("%w: expected argument between ','", errs.SyntaxError), ts[0].Debug)
+		}
+
+		if b.isRest(part) {
+			if i != len(parts)-1 {
+				return nil, errs.WithDebug(fmt.Errorf("%w: variadic argument must be the last argument", errs.SyntaxError), part[0].Debug)
+			}
+
+			args = append(args, &models.Node{
+				Type:    tokens.Identifier,
+				Content: part[3].Value,
+				Flags:   []string{"variadic"},
+				Debug:   part[3].Debug,
+			})
+			continue
+		}
+
+		name := part[0]
+		if name.Type != tokens.Identifier {
+			return nil, errs.WithDebug(fmt.Errorf("%w: expected argument name, but got '%s'", errs.SyntaxError, name.Value), name.Debug)
+		}
+
+		arg := &models.Node{
+			Type:    tokens.Identifier,
+			Content: name.Value,
+			Debug:   name.Debug,
+		}
+
+		rest := part[1:]
+		if len(rest) > 0 && rest[0].Type == tokens.Colon {
+			inx := 1
+			typ, err := b.parseType(rest, &inx, rest[0])
+			if err != nil {
+				return nil, err
+			}
+
+			arg.Map = map[string]any{"type": typ}
+			rest = rest[inx:]
+		}
+
+		if len(rest) > 0 {
+			if rest[0].Type != tokens.Assign {
+				return nil, errs.WithDebug(fmt.Errorf("%w: expected ',' between arguments", errs.SyntaxError), rest[0].Debug)
+			}
+
+			if len(rest) == 1 {
+				return nil, errs.WithDebug(fmt.Errorf("%w: expected default value for argument %s", errs.SyntaxError, name.Value), rest[0].Debug)
+			}
+
+			value, err := b.Build(append(rest[1:], SemiColonToken))
+			if err != nil {
+				return nil, err
+			}
+
+			arg.VariableType = tokens.ExpressionVariable
+			arg.Children = value
+			arg.Flags = []string{"default"}
+		} else if len(args) > 0 && args[len(args)-1].HasFlag("default") {
+			return nil, errs.WithDebug(fmt.Errorf("%w: argument %s without default value follows an optional argument", errs.SyntaxError, name.Value), name.Debug)
+		}
+
+		args = append(args, arg)
+	}
+
+	return args, nil
+}
+
 func (b *Builder) parseFunctionCall(ts []*models.Token, inx *int, node *models.Node) (*models.Node, error) {
 	*inx++
 
@@ -262,13 +337,24 @@ func (b *Builder) parseReturn(ts []*models.Token, inx *int) (*models.Node, error
 		return node, nil
 	}
 
-	var children = []*models.Token{}
+	var (
+		children   = []*models.Token{}
+		braceCount int
+	)
 	for {
 		if *inx >= len(ts) {
 			break
 		}
 
-		if ts[*inx].Type == tokens.Semicolon {
+		if ts[*inx].Type == tokens.LeftBrace {
+			braceCount++
+		}
+
+		if ts[*inx].Type == tokens.RightBrace {
+			braceCount--
+		}
+
+		if ts[*inx].Type == tokens.Semicolon && braceCount == 0 {
 			*inx++
 			break
 		}
@@ -287,12 +373,51 @@ func (b *Builder) parseReturn(ts []*models.Token, inx *int) (*models.Node, error
 	return node, nil
 }
 
+// parseYield parses a yield statement of a generator function, it takes a value like return
+func (b *Builder) parseYield(ts []*models.Token, inx *int) (*models.Node, error) {
+	token := ts[*inx]
+	if *inx+1 >= len(ts) || ts[*inx+1].Type == tokens.Semicolon {
+		return nil, errs.WithDebug(fmt.Errorf("%w: expected value after yield", errs.SyntaxError), token.Debug)
+	}
+
+	node, err := b.parseReturn(ts, inx)
+	if err != nil {
+		return nil, err
+	}
+
+	node.Content = "yield"
+	return node, nil
+}
+
+// parseDefer parses a defer statement, the expression is evaluated when the function finishes
+func (b *Builder) parseDefer(ts []*models.Token, inx *int) (*models.Node, error) {
+	token := ts[*inx]
+	if *inx+1 >= len(ts) || ts[*inx+1].Type == tokens.Semicolon {
+		return nil, errs.WithDebug(fmt.Errorf("%w: expected expression after defer", errs.SyntaxError), token.Debug)
+	}
+
+	node, err := b.parseReturn(ts, inx)
+	if err != nil {
+		return nil, err
+	}
+
+	node.Content = "defer"
+	return node, nil
+}
+
 func (b *Builder) parseFuncCallArg(ts []*models.Token, inx *int) (*models.Node, error) {
 	var (
 		children   []*models.Token
 		parenCount = 1
+		named      *models.Token
 	)
 
+	// named arguments like `f(name: value)`
+	if *inx+1 < len(ts) && ts[*inx].Type == tokens.Identifier && ts[*inx+1].Type == tokens.Colon {
+		named = ts[*inx]
+		*inx += 2
+	}
+
 	for {
 		if *inx >= len(ts) {
 			return nil, errs.WithDebug(fmt.Errorf("%w: expected ')', but got 'EOF'", errs.SyntaxError), ts[*inx-1].Debug)
@@ -366,11 +491,26 @@ func (b *Builder) parseFuncCallArg(ts []*models.Token, inx *int) (*models.Node,
 
 	children = append(children, SemiColonToken)
 
+	if named != nil && len(children) == 1 {
+		return nil, errs.WithDebug(fmt.Errorf("%w: expected value for argument %s", errs.SyntaxError, named.Value), named.Debug)
+	}
+
 	nodes, err := b.Build(children)
 	if err != nil {
 		return nil, err
 	}
 
+	if named != nil {
+		return &models.Node{
+			Type:         tokens.FuncArg,
+			Content:      named.Value,
+			VariableType: tokens.ExpressionVariable,
+			Children:     nodes,
+			Flags:        []string{"named"},
+			Debug:        named.Debug,
+		}, nil
+	}
+
 	if len(nodes) == 1 && nodes[0].Type != tokens.FuncCall {
 		return nodes[0], nil
 	}
@@ -383,3 +523,50 @@ func (b *Builder) parseFuncCallArg(ts []*models.Token, inx *int) (*models.Node,
 		Debug:        ts[*inx-1].Debug,
 	}, nil
 }
+
+// parseDecorators parses the decorators of a function or definition declaration,
+// like `@memoize` or `@route("GET", "/users")`, and the declaration after them.
+// The decorators are stored in the order they are written.
+func (b *Builder) parseDecorators(ts []*models.Token, inx *int) (*models.Node, error) {
+	var decorators []*models.Node
+
+	for *inx < len(ts) && ts[*inx].Type == tokens.At {
+		token := ts[*inx]
+		*inx++
+
+		if *inx >= len(ts) || ts[*inx].Type != tokens.Identifier {
+			return nil, errs.WithDebug(fmt.Errorf("%w: expected decorator name after '@'", errs.SyntaxError), token.Debug)
+		}
+
+		decorator, err := b.parseIdentifier(ts, inx)
+		if err != nil {
+			return nil, err
+		}
+
+		if decorator.VariableType != tokens.ReferenceVariable && decorator.VariableType != tokens.FunctionCallVariable {
+			return nil, errs.WithDebug(fmt.Errorf("%w: expected decorator name or call after '@'", errs.SyntaxError), token.Debug)
+		}
+
+		decorators = append(decorators, decorator)
+	}
+
+	if *inx >= len(ts) || (ts[*inx].Type != tokens.Function && ts[*inx].Type != tokens.Define) {
+		return nil, errs.WithDebug(fmt.Errorf("%w: decorators can only be used on fn and define declarations", errs.SyntaxError), ts[*inx-1].Debug)
+	}
+
+	node, err := b.buildNode(ts, inx)
+	if err != nil {
+		return nil, err
+	}
+
+	if node.Type != tokens.Function && node.Type != tokens.Define {
+		return nil, errs.WithDebug(fmt.Errorf("%w: decorators can only be used on fn and define declarations", errs.SyntaxError), node.Debug)
+	}
+
+	if node.Map == nil {
+		node.Map = map[string]any{}
+	}
+	node.Map["decorators"] = decorators
+
+	return node, nil
+}
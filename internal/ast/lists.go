@@ -99,6 +99,10 @@ func (b *Builder) parseList(ts []*models.Token, inx *int) (*models.Node, error)
 		return node, nil
 	}
 
+	if pos := b.comprehensionFor(ts, *inx); pos >= 0 {
+		return b.parseComprehension(ts, inx, pos)
+	}
+
 	var (
 		children     []*models.Node
 		bracketCount = 1
@@ -145,6 +149,12 @@ func (b *Builder) parseList(ts []*models.Token, inx *int) (*models.Node, error)
 		return nil, errs.WithDebug(fmt.Errorf("%w: expected ';', but got 'EOF'", errs.SyntaxError), node.Debug)
 	}
 
+	// the list is part of an expression, like `[1, 2] |> map(double)` or `ok ? [1] : []`
+	if b.isExpression(ts[*inx]) || ts[*inx].Type == tokens.Colon {
+		node.Children = children
+		return node, nil
+	}
+
 	if ts[*inx].Type != tokens.Semicolon && ts[*inx].Type != tokens.LeftBracket {
 		return nil, errs.WithDebug(fmt.Errorf("%w: expected ';', but got '%s'", errs.SyntaxError, ts[*inx].Type), ts[*inx].Debug)
 	}
@@ -154,6 +164,164 @@ func (b *Builder) parseList(ts []*models.Token, inx *int) (*models.Node, error)
 	return node, nil
 }
 
+// comprehensionFor returns the position of the `for` of a list comprehension
+// like `[x * 2 for x in items if x > 0]`, or -1 if the list starting at inx is a list literal
+func (b *Builder) comprehensionFor(ts []*models.Token, inx int) int {
+	depth := 0
+	for i := inx; i < len(ts); i++ {
+		if depth == 0 && ts[i].Type == tokens.RightBracket {
+			return -1
+		}
+
+		if depth == 0 && ts[i].Type == tokens.For {
+			return i
+		}
+
+		depth += b.depthChange(ts[i])
+		if depth < 0 {
+			return -1
+		}
+	}
+	return -1
+}
+
+// parseComprehension parses a list comprehension, inx is after the '[' and pos is the position of the `for`.
+// The node is built like a for loop: the arguments are the loop variable or pattern and the iterable,
+// the first child is the value of the items and the optional second child is the `if` condition.
+func (b *Builder) parseComprehension(ts []*models.Token, inx *int, pos int) (*models.Node, error) {
+	token := ts[pos]
+	node := &models.Node{
+		Type:         tokens.For,
+		VariableType: tokens.ComprehensionVariable,
+		Content:      "comprehension",
+		Debug:        ts[*inx-1].Debug,
+	}
+
+	if pos == *inx {
+		return nil, errs.WithDebug(fmt.Errorf("%w: expected value before 'for'", errs.SyntaxError), token.Debug)
+	}
+
+	value, err := b.parseExpressionTokens(ts[*inx:pos], tokens.ListValue, "value")
+	if err != nil {
+		return nil, err
+	}
+
+	*inx = pos + 1
+	if *inx >= len(ts) {
+		return nil, errs.WithDebug(fmt.Errorf("%w: expected identifier or pattern, but got 'EOF'", errs.SyntaxError), token.Debug)
+	}
+
+	var iterator *models.Node
+	switch ts[*inx].Type {
+	case tokens.Identifier:
+		iterator = &models.Node{
+			Type:    tokens.Identifier,
+			Content: ts[*inx].Value,
+			Debug:   ts[*inx].Debug,
+		}
+		*inx++
+	case tokens.LeftBracket, tokens.LeftBrace:
+		pattern, err := b.collectPattern(ts, inx)
+		if err != nil {
+			return nil, err
+		}
+
+		iterator, err = b.parsePattern(pattern, pattern[0].Debug)
+		if err != nil {
+			return nil, err
+		}
+		node.Flags = append(node.Flags, "destructure")
+	default:
+		return nil, errs.WithDebug(fmt.Errorf("%w: expected identifier or pattern, but got '%s'", errs.SyntaxError, ts[*inx].Type), ts[*inx].Debug)
+	}
+
+	if *inx >= len(ts) || ts[*inx].Type != tokens.In {
+		return nil, errs.WithDebug(fmt.Errorf("%w: expected 'in' after the comprehension variable", errs.SyntaxError), token.Debug)
+	}
+	*inx++
+
+	var (
+		iterable, condition []*models.Token
+		isCondition         bool
+		depth               int
+	)
+
+	for {
+		if *inx >= len(ts) {
+			return nil, errs.WithDebug(fmt.Errorf("%w: expected ']', but got 'EOF'", errs.SyntaxError), token.Debug)
+		}
+
+		current := ts[*inx]
+		*inx++
+
+		if depth == 0 && current.Type == tokens.RightBracket {
+			break
+		}
+
+		if depth == 0 && current.Type == tokens.If && !isCondition {
+			isCondition = true
+			continue
+		}
+
+		depth += b.depthChange(current)
+		if isCondition {
+			condition = append(condition, current)
+		} else {
+			iterable = append(iterable, current)
+		}
+	}
+
+	if len(iterable) == 0 {
+		return nil, errs.WithDebug(fmt.Errorf("%w: expected iterable expression after 'in'", errs.SyntaxError), token.Debug)
+	}
+
+	iterableNode, err := b.parseExpressionTokens(iterable, tokens.In, "iterable")
+	if err != nil {
+		return nil, err
+	}
+
+	node.Args = []*models.Node{iterator, iterableNode}
+	node.Children = []*models.Node{value}
+
+	if isCondition {
+		if len(condition) == 0 {
+			return nil, errs.WithDebug(fmt.Errorf("%w: expected condition after 'if'", errs.SyntaxError), token.Debug)
+		}
+
+		conditionNode, err := b.parseExpressionTokens(condition, tokens.If, "condition")
+		if err != nil {
+			return nil, err
+		}
+		node.Children = append(node.Children, conditionNode)
+	}
+
+	if *inx < len(ts) && ts[*inx].Type == tokens.LeftBracket {
+		return b.parseObjectAccess(ts, inx, node)
+	}
+
+	if *inx < len(ts) && ts[*inx].Type == tokens.Semicolon {
+		*inx++
+	}
+
+	return node, nil
+}
+
+// parseExpressionTokens builds the tokens into an expression node of the given type
+func (b *Builder) parseExpressionTokens(ts []*models.Token, typ tokens.TokenType, content string) (*models.Node, error) {
+	children, err := b.Build(append(append([]*models.Token{}, ts...), SemiColonToken))
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Node{
+		Type:         typ,
+		VariableType: tokens.ExpressionVariable,
+		Content:      content,
+		Children:     children,
+		Debug:        ts[0].Debug,
+	}, nil
+}
+
 func (b *Builder) parseListValue(ts []*models.Token, inx *int) (*models.Node, bool, error) {
 	var (
 		children     []*models.Token
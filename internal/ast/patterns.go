@@ -0,0 +1,335 @@
+package ast
+
+import (
+	"fmt"
+
+	"github.com/flarelang/flare/internal/errs"
+	"github.com/flarelang/flare/internal/models"
+	"github.com/flarelang/flare/internal/tokens"
+)
+
+// parsePattern parses the tokens of a single pattern.
+//
+// Supported patterns are:
+//   - `else` matches anything
+//   - `name` binds the value to name, `_` matches without binding
+//   - `name: type` binds the value if it is of the given type
+//   - `a.b` matches if the value equals the referenced value
+//   - literals like `1`, `-2.5`, `"text"`, `true` or `nil`
+//   - `[first, second, ...rest]` matches lists
+//   - `{key, other: pattern}` matches arrays and definition instances
+func (b *Builder) parsePattern(ts []*models.Token, debug *models.Debug) (*models.Node, error) {
+	if len(ts) == 0 {
+		return nil, errs.WithDebug(fmt.Errorf("%w: expected pattern", errs.SyntaxError), debug)
+	}
+
+	first := ts[0]
+
+	switch first.Type {
+	case tokens.Else:
+		if len(ts) != 1 {
+			return nil, errs.WithDebug(fmt.Errorf("%w: unexpected '%s' after 'else'", errs.SyntaxError, ts[1].Value), ts[1].Debug)
+		}
+
+		return &models.Node{
+			Type:    tokens.Else,
+			Content: "else",
+			Debug:   first.Debug,
+		}, nil
+	case tokens.LeftBracket:
+		return b.parseListPattern(ts)
+	case tokens.LeftBrace:
+		return b.parseArrayPattern(ts)
+	case tokens.String, tokens.Number, tokens.Bool, tokens.Nil:
+		if len(ts) != 1 {
+			return nil, errs.WithDebug(fmt.Errorf("%w: unexpected '%s' in pattern", errs.SyntaxError, ts[1].Value), ts[1].Debug)
+		}
+
+		return &models.Node{
+			Type:         first.Type,
+			VariableType: tokens.InlineValue,
+			Content:      "pattern",
+			Value:        b.getValue(first),
+			Debug:        first.Debug,
+		}, nil
+	case tokens.Subtraction:
+		if len(ts) != 2 || ts[1].Type != tokens.Number {
+			return nil, errs.WithDebug(fmt.Errorf("%w: expected number after '-' in pattern", errs.SyntaxError), first.Debug)
+		}
+
+		var value any
+		switch v := b.getValue(ts[1]).(type) {
+		case int:
+			value = -v
+		case float64:
+			value = -v
+		}
+
+		return &models.Node{
+			Type:         tokens.Number,
+			VariableType: tokens.InlineValue,
+			Content:      "pattern",
+			Value:        value,
+			Debug:        first.Debug,
+		}, nil
+	case tokens.Identifier:
+		if len(ts) == 1 {
+			return &models.Node{
+				Type:    tokens.Identifier,
+				Content: first.Value,
+				Debug:   first.Debug,
+			}, nil
+		}
+
+		if ts[1].Type == tokens.Colon {
+			typ, err := b.parseTypeName(ts[2:], ts[1].Debug)
+			if err != nil {
+				return nil, err
+			}
+
+			return &models.Node{
+				Type:    tokens.Identifier,
+				Content: first.Value,
+				Value:   typ,
+				Debug:   first.Debug,
+			}, nil
+		}
+
+		name, err := b.parseDottedName(ts)
+		if err != nil {
+			return nil, err
+		}
+
+		return &models.Node{
+			Type:         tokens.Identifier,
+			VariableType: tokens.ReferenceVariable,
+			Reference:    true,
+			Content:      name,
+			Debug:        first.Debug,
+		}, nil
+	}
+
+	return nil, errs.WithDebug(fmt.Errorf("%w: invalid pattern '%s'", errs.SyntaxError, first.Value), first.Debug)
+}
+
+func (b *Builder) parseListPattern(ts []*models.Token) (*models.Node, error) {
+	first := ts[0]
+	last := ts[len(ts)-1]
+
+	if last.Type != tokens.RightBracket {
+		return nil, errs.WithDebug(fmt.Errorf("%w: expected ']' at the end of list pattern, but got '%s'", errs.SyntaxError, last.Value), last.Debug)
+	}
+
+	node := &models.Node{
+		Type:    tokens.List,
+		Content: "pattern",
+		Debug:   first.Debug,
+	}
+
+	elements := b.splitTopLevel(ts[1:len(ts)-1], tokens.Comma)
+	for i, element := range elements {
+		if len(element) == 0 {
+			return nil, errs.WithDebug(fmt.Errorf("%w: expected pattern between ','", errs.SyntaxError), first.Debug)
+		}
+
+		if b.isRest(element) {
+			if i != len(elements)-1 {
+				return nil, errs.WithDebug(fmt.Errorf("%w: rest pattern must be the last element", errs.SyntaxError), element[0].Debug)
+			}
+
+			node.Children = append(node.Children, &models.Node{
+				Type:    tokens.Identifier,
+				Content: element[3].Value,
+				Flags:   []string{"rest"},
+				Debug:   element[0].Debug,
+			})
+			continue
+		}
+
+		child, err := b.parsePattern(element, first.Debug)
+		if err != nil {
+			return nil, err
+		}
+		node.Children = append(node.Children, child)
+	}
+
+	return node, nil
+}
+
+func (b *Builder) parseArrayPattern(ts []*models.Token) (*models.Node, error) {
+	first := ts[0]
+	last := ts[len(ts)-1]
+
+	if last.Type != tokens.RightBrace {
+		return nil, errs.WithDebug(fmt.Errorf("%w: expected '}' at the end of array pattern, but got '%s'", errs.SyntaxError, last.Value), last.Debug)
+	}
+
+	node := &models.Node{
+		Type:    tokens.Array,
+		Content: "pattern",
+		Debug:   first.Debug,
+	}
+
+	for _, element := range b.splitTopLevel(ts[1:len(ts)-1], tokens.Comma) {
+		if len(element) == 0 {
+			return nil, errs.WithDebug(fmt.Errorf("%w: expected key between ','", errs.SyntaxError), first.Debug)
+		}
+
+		key := element[0]
+		if key.Type != tokens.Identifier && key.Type != tokens.String {
+			return nil, errs.WithDebug(fmt.Errorf("%w: expected identifier or string key, but got '%s'", errs.SyntaxError, key.Value), key.Debug)
+		}
+
+		keyName, _ := b.getValue(key).(string)
+		entry := &models.Node{
+			Type:    tokens.ArrayKeyValuePair,
+			Content: keyName,
+			Debug:   key.Debug,
+		}
+
+		if len(element) == 1 {
+			if key.Type != tokens.Identifier {
+				return nil, errs.WithDebug(fmt.Errorf("%w: string key %s needs a pattern", errs.SyntaxError, key.Value), key.Debug)
+			}
+
+			entry.Children = []*models.Node{{
+				Type:    tokens.Identifier,
+				Content: keyName,
+				Debug:   key.Debug,
+			}}
+			node.Children = append(node.Children, entry)
+			continue
+		}
+
+		if element[1].Type != tokens.Colon {
+			return nil, errs.WithDebug(fmt.Errorf("%w: expected ':' or ',', but got '%s'", errs.SyntaxError, element[1].Value), element[1].Debug)
+		}
+
+		child, err := b.parsePattern(element[2:], element[1].Debug)
+		if err != nil {
+			return nil, err
+		}
+
+		entry.Children = []*models.Node{child}
+		node.Children = append(node.Children, entry)
+	}
+
+	return node, nil
+}
+
+// collectPattern collects the tokens of a bracketed list or array pattern
+// starting at the opening bracket, leaving the index after the closing one
+func (b *Builder) collectPattern(ts []*models.Token, inx *int) ([]*models.Token, error) {
+	var (
+		pattern []*models.Token
+		depth   int
+		start   = ts[*inx]
+	)
+
+	for {
+		if *inx >= len(ts) {
+			return nil, errs.WithDebug(fmt.Errorf("%w: unclosed pattern, got 'EOF'", errs.SyntaxError), start.Debug)
+		}
+
+		depth += b.depthChange(ts[*inx])
+		pattern = append(pattern, ts[*inx])
+		*inx++
+
+		if depth == 0 {
+			return pattern, nil
+		}
+	}
+}
+
+// parseTypeName parses a type name like `int`, `array`, `html.Builder` or "<Object:int>"
+func (b *Builder) parseTypeName(ts []*models.Token, debug *models.Debug) (string, error) {
+	if len(ts) == 0 {
+		return "", errs.WithDebug(fmt.Errorf("%w: expected type name", errs.SyntaxError), debug)
+	}
+
+	if len(ts) == 1 {
+		switch ts[0].Type {
+		case tokens.String:
+			return b.getValue(ts[0]).(string), nil
+		case tokens.Array, tokens.Nil, tokens.Error, tokens.Identifier:
+			return ts[0].Value, nil
+		}
+	}
+
+	return b.parseDottedName(ts)
+}
+
+// parseDottedName joins tokens like `app.models.User` into a single name
+func (b *Builder) parseDottedName(ts []*models.Token) (string, error) {
+	var name string
+
+	for i, t := range ts {
+		expected := tokens.Identifier
+		if i%2 == 1 {
+			expected = tokens.Dot
+		}
+
+		if t.Type != expected {
+			return "", errs.WithDebug(fmt.Errorf("%w: expected '%s', but got '%s'", errs.SyntaxError, expected, t.Value), t.Debug)
+		}
+
+		name += t.Value
+	}
+
+	if ts[len(ts)-1].Type != tokens.Identifier {
+		return "", errs.WithDebug(fmt.Errorf("%w: expected identifier after '.'", errs.SyntaxError), ts[len(ts)-1].Debug)
+	}
+
+	return name, nil
+}
+
+// isRest reports whether the tokens are a `...name` rest pattern
+func (b *Builder) isRest(ts []*models.Token) bool {
+	return len(ts) == 4 &&
+		ts[0].Type == tokens.Dot &&
+		ts[1].Type == tokens.Dot &&
+		ts[2].Type == tokens.Dot &&
+		ts[3].Type == tokens.Identifier
+}
+
+// splitTopLevel splits the tokens at the separator, skipping separators nested in brackets
+func (b *Builder) splitTopLevel(ts []*models.Token, separator tokens.TokenType) [][]*models.Token {
+	var (
+		parts   [][]*models.Token
+		current []*models.Token
+		depth   int
+	)
+
+	if len(ts) == 0 {
+		return nil
+	}
+
+	for _, t := range ts {
+		if depth == 0 && t.Type == separator {
+			parts = append(parts, current)
+			current = nil
+			continue
+		}
+
+		depth += b.depthChange(t)
+		current = append(current, t)
+	}
+
+	// allow a trailing separator
+	if len(current) > 0 {
+		parts = append(parts, current)
+	}
+
+	return parts
+}
+
+// depthChange returns how the token changes the bracket nesting depth
+func (b *Builder) depthChange(t *models.Token) int {
+	switch t.Type {
+	case tokens.LeftParenthesis, tokens.LeftBracket, tokens.LeftBrace:
+		return 1
+	case tokens.RightParenthesis, tokens.RightBracket, tokens.RightBrace:
+		return -1
+	}
+	return 0
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/flarelang/flare/internal/errs"
 	"github.com/flarelang/flare/internal/models"
@@ -110,5 +111,37 @@ func (b *Builder) isExpression(n *models.Token) bool {
 		n.Type == tokens.And ||
 		n.Type == tokens.Or ||
 		n.Type == tokens.Not ||
-		n.Type == tokens.Power
+		n.Type == tokens.Power ||
+		n.Type == tokens.Pipe ||
+		n.Type == tokens.Question ||
+		n.Type == tokens.Coalesce
+}
+
+// isAssignment reports whether the token is `=` or a compound assignment like `+=`
+func (b *Builder) isAssignment(t *models.Token) bool {
+	switch t.Type {
+	case tokens.Assign, tokens.AdditionAssign, tokens.SubtractionAssign, tokens.MultiplicationAssign, tokens.DivisionAssign, tokens.CoalesceAssign:
+		return true
+	default:
+		return false
+	}
+}
+
+// isWord reports whether the token is an identifier or a keyword,
+// keywords can be used as member names after a dot, like `errors.as`
+func (b *Builder) isWord(t *models.Token) bool {
+	if t.Type == tokens.Identifier {
+		return true
+	}
+
+	if t.Type == tokens.String || t.Type == tokens.TemplateLiteral || t.Value == "" {
+		return false
+	}
+
+	for i, r := range t.Value {
+		if !unicode.IsLetter(r) && r != '_' && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return true
 }
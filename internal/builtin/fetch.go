@@ -1,6 +1,7 @@
 package builtin
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -13,6 +14,7 @@ import (
 )
 
 type fetchConfig struct {
+	ctx     context.Context
 	url     string
 	method  string
 	body    io.Reader
@@ -20,13 +22,15 @@ type fetchConfig struct {
 	debug   *models.Debug
 }
 
-func fnFetch(args []lang.Object) (lang.Object, error) {
+// fnFetch sends an http request, it is cancelled with the context of the caller or the context of the config
+func fnFetch(ctx context.Context, args []lang.Object) (lang.Object, error) {
 	url, ok := args[0].Value().(string)
 	if !ok {
 		return nil, errs.WithDebug(fmt.Errorf("invalid argument type for url, want: string, got: %s", args[0].Type()), args[0].Debug())
 	}
 
 	var conf = &fetchConfig{
+		ctx:   ctx,
 		url:   url,
 		debug: args[0].Debug(),
 	}
@@ -49,6 +53,14 @@ func fnFetch(args []lang.Object) (lang.Object, error) {
 		conf.method = strings.ToUpper(method.Value().(string))
 	}
 
+	if rawCtx, ok := argConfig.Access("context"); ok {
+		c, ok := rawCtx.(*lang.Context)
+		if !ok {
+			return nil, errs.WithDebug(fmt.Errorf("invalid argument type for config.context, want: context, got: %s", lang.TypeOf(rawCtx)), rawCtx.Debug())
+		}
+		conf.ctx = c.Context()
+	}
+
 	if body, ok := argConfig.Access("body"); ok {
 		conf.body = strings.NewReader(body.String())
 	}
@@ -80,11 +92,11 @@ func fnFetch(args []lang.Object) (lang.Object, error) {
 }
 
 func fnRealFetch(conf *fetchConfig) (lang.Object, error) {
-	req, err := http.NewRequest(conf.method, conf.url, conf.body)
-	req.Header.Set("User-Agent", "Flare-Http-Fetch/1.0")
+	req, err := http.NewRequestWithContext(conf.ctx, conf.method, conf.url, conf.body)
 	if err != nil {
 		return nil, err
 	}
+	req.Header.Set("User-Agent", "Flare-Http-Fetch/1.0")
 
 	for key, values := range conf.headers {
 		for _, value := range values {
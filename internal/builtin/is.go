@@ -13,6 +13,9 @@ func setIsMethods(m map[string]lang.Method) map[string]lang.Method {
 	m["isBool"] = lang.NewFunction(is(toBool)).WithArg("object")
 	m["isInstanceOf"] = lang.NewFunction(isInstaceOf).
 		WithArg("type").WithArg("value")
+	m["instanceOf"] = lang.NewFunction(func(args []lang.Object) (lang.Object, error) {
+		return isInstaceOf([]lang.Object{args[1], args[0]})
+	}).WithArg("object").WithArg("type")
 
 	return m
 }
@@ -24,15 +27,17 @@ func is(exec lang.ExecFunc) lang.ExecFunc {
 	}
 }
 
+// isInstaceOf reports whether the value is an instance of the definition,
+// one of its child definitions or a definition implementing the interface
 func isInstaceOf(args []lang.Object) (lang.Object, error) {
-	typ, ok := args[0].(*lang.Definition)
-	if !ok {
-		return nil, errs.WithDebug(fmt.Errorf("type must be a definition"), args[0].Debug())
-	}
 	inst, ok := args[1].(*lang.Instance)
-	if !ok {
-		return lang.NewBool("ok", false, args[1].Debug()), nil
+
+	switch typ := args[0].(type) {
+	case *lang.Definition:
+		return lang.NewBool("ok", ok && inst.Definition().Extends(typ), args[1].Debug()), nil
+	case *lang.Interface:
+		return lang.NewBool("ok", ok && inst.Definition().Implements(typ), args[1].Debug()), nil
 	}
 
-	return lang.NewBool("ok", inst.Definition().Type() == typ.Type(), args[1].Debug()), nil
+	return nil, errs.WithDebug(fmt.Errorf("type must be a definition or an interface"), args[0].Debug())
 }
@@ -46,7 +46,8 @@ func GetMethods(importer ImportFunc, evaler EvalFunc, provider *state.Provider)
 	}).WithArg("object")
 
 	m["map"] = lang.NewFunction(fnMap).WithArgs([]string{"fn", "object"})
-	m["fetch"] = lang.NewFunction(fnFetch).
+	m["filter"] = lang.NewFunction(fnFilter).WithArgs([]string{"fn", "list"})
+	m["fetch"] = lang.NewContextFunction(fnFetch).
 		WithArg("url").WithVariadicArg("config")
 	m["state"] = lang.NewFunction(func(args []lang.Object) (lang.Object, error) {
 		if provider == nil {
@@ -140,12 +141,7 @@ func (i *Import) Execute(args []lang.Object) (lang.Object, error) {
 // fnType returns the type of the given object.
 func fnType(args []lang.Object) (lang.Object, error) {
 	obj := args[0]
-
-	if ts, ok := obj.(interface{ TypeString() string }); ok {
-		return lang.NewString("type", ts.TypeString(), obj.Debug()), nil
-	}
-
-	return lang.NewString("type", string(obj.Type()), obj.Debug()), nil
+	return lang.NewString("type", lang.TypeOf(obj), obj.Debug()), nil
 }
 
 // fnRange returns a range object.
@@ -268,3 +264,42 @@ func fnMap(args []lang.Object) (lang.Object, error) {
 
 	return lang.NewList("map", result, args[1].Debug()), nil
 }
+
+// fnFilter returns the items of the list the function returns true for
+func fnFilter(args []lang.Object) (lang.Object, error) {
+	if args[0].Type() != lang.TFnRef {
+		return nil, fmt.Errorf("expected %s, got %v", lang.TFnRef.String(), args[0].Type())
+	}
+
+	fn := args[0].Value().(*lang.Fn).Fn
+	fnArgs := fn.Args()
+
+	if len(fnArgs) != 1 {
+		return nil, fmt.Errorf("expected a function with 1 argument, got %d", len(fnArgs))
+	}
+
+	if args[1].Type() != lang.TList {
+		return nil, fmt.Errorf("expected %s, got %v", lang.TList.String(), args[1].Type())
+	}
+
+	var result []lang.Object
+	for _, item := range args[1].Value().([]lang.Object) {
+		cp := item.Copy()
+		cp.Rename(fnArgs[0])
+
+		ok, err := fn.Execute([]lang.Object{cp})
+		if err != nil {
+			return nil, err
+		}
+
+		if ok == nil || ok.Type() != lang.TBool {
+			return nil, fmt.Errorf("filter function must return %s", lang.TBool.String())
+		}
+
+		if ok.Value().(bool) {
+			result = append(result, item)
+		}
+	}
+
+	return lang.NewList("filter", result, args[1].Debug()), nil
+}
@@ -14,6 +14,11 @@ import (
 
 // WithDebug adds debug information to an error
 func WithDebug(err error, debug *models.Debug) error {
+	// Joined errors are kept as they are, every error has its own debug information
+	if je, ok := err.(JoinedError); ok {
+		return je
+	}
+
 	// Check if the error is already a DebugError
 	var de DebugError
 	if errors.As(err, &de) {
@@ -55,18 +60,29 @@ func (de DebugError) GetParentError() error {
 	return de.err
 }
 
+// Unwrap returns the wrapped error, so errors.Is and errors.As can see through the debug information
+func (de DebugError) Unwrap() error {
+	return de.err
+}
+
 func (de DebugError) getNear(pf func(r io.Reader) string) string {
-	if de.debug == nil {
+	return Near(de.debug, pf)
+}
+
+// Near renders the code near the debug position with line numbers,
+// pf can highlight the code before it is numbered
+func Near(debug *models.Debug, pf func(r io.Reader) string) string {
+	if debug == nil {
 		return ""
 	}
 
 	var near string
 
-	parts := strings.Split(pf(strings.NewReader(de.debug.Near)), "\n")
-	maxLineNumLen := len(strconv.Itoa(de.debug.Line + len(parts) - 1))
+	parts := strings.Split(pf(strings.NewReader(debug.Near)), "\n")
+	maxLineNumLen := len(strconv.Itoa(debug.Line + len(parts) - 1))
 
 	for i, part := range parts {
-		lineNum := de.debug.Line + i
+		lineNum := debug.Line + i
 		lineNumStr := strconv.Itoa(lineNum)
 		lineNumStr = strings.Repeat(" ", maxLineNumLen-len(lineNumStr)) + lineNumStr
 		near += fmt.Sprintf("%s %s", color.New(color.FgHiBlack).Sprint(lineNumStr+" |"), part)
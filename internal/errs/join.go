@@ -0,0 +1,46 @@
+package errs
+
+import "strings"
+
+// JoinedError holds multiple errors that happened together,
+// like an error and the errors of the deferred expressions that ran after it
+type JoinedError struct {
+	errs []error
+}
+
+// Join joins the non-nil errors, it returns nil if there is none
+// and the error itself if there is only one
+func Join(errs ...error) error {
+	var joined []error
+	for _, err := range errs {
+		if err != nil {
+			joined = append(joined, err)
+		}
+	}
+
+	switch len(joined) {
+	case 0:
+		return nil
+	case 1:
+		return joined[0]
+	}
+	return JoinedError{errs: joined}
+}
+
+func (je JoinedError) Error() string {
+	messages := make([]string, len(je.errs))
+	for i, err := range je.errs {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Unwrap returns the joined errors, so errors.Is and errors.As can see each of them
+func (je JoinedError) Unwrap() []error {
+	return je.errs
+}
+
+// Errors returns the joined errors
+func (je JoinedError) Errors() []error {
+	return je.errs
+}
@@ -126,6 +126,19 @@ func (lx *Lexer) parse(s string) ([]*models.Token, error) {
 						pos++
 					}
 				}
+			} else if pos+1 < len(s) && runes[pos+1] == '=' {
+				parsed = append(parsed, &models.Token{
+					Type:  tokens.DivisionAssign,
+					Value: "/=",
+					Debug: &models.Debug{
+						Line:   line,
+						Column: col,
+						File:   lx.filename,
+						Near:   lx.near(s, pos, fileLen),
+					},
+				})
+				col += 2
+				pos++
 			} else {
 				parsed = append(parsed, &models.Token{
 					Type:  tokens.Division,
@@ -215,7 +228,7 @@ func (lx *Lexer) parse(s string) ([]*models.Token, error) {
 				},
 			})
 			col++
-		case ';', ':', ',', '.', '(', ')', '{', '}', '[', ']':
+		case ';', ':', ',', '.', '(', ')', '{', '}', '[', ']', '@':
 			ch := runes[pos]
 			parsed = append(parsed, &models.Token{
 				Type:  lx.getCharIdent(ch),
@@ -332,6 +345,19 @@ func (lx *Lexer) parse(s string) ([]*models.Token, error) {
 				})
 				col += 2
 				pos++
+			} else if pos+1 < len(s) && runes[pos+1] == '>' {
+				parsed = append(parsed, &models.Token{
+					Type:  tokens.Pipe,
+					Value: "|>",
+					Debug: &models.Debug{
+						Line:   line,
+						Column: col,
+						File:   lx.filename,
+						Near:   lx.near(s, pos, fileLen),
+					},
+				})
+				col += 2
+				pos++
 			} else {
 				parsed = append(parsed, &models.Token{
 					Type:  tokens.Or,
@@ -358,6 +384,18 @@ func (lx *Lexer) parse(s string) ([]*models.Token, error) {
 					},
 				})
 				pos++
+			} else if pos+1 < len(s) && runes[pos+1] == '=' {
+				parsed = append(parsed, &models.Token{
+					Type:  tokens.AdditionAssign,
+					Value: "+=",
+					Debug: &models.Debug{
+						Line:   line,
+						Column: col,
+						File:   lx.filename,
+						Near:   lx.near(s, pos, fileLen),
+					},
+				})
+				pos++
 			} else {
 				parsed = append(parsed, &models.Token{
 					Type:  tokens.Addition,
@@ -384,6 +422,18 @@ func (lx *Lexer) parse(s string) ([]*models.Token, error) {
 					},
 				})
 				pos++
+			} else if pos+1 < len(s) && runes[pos+1] == '=' {
+				parsed = append(parsed, &models.Token{
+					Type:  tokens.SubtractionAssign,
+					Value: "-=",
+					Debug: &models.Debug{
+						Line:   line,
+						Column: col,
+						File:   lx.filename,
+						Near:   lx.near(s, pos, fileLen),
+					},
+				})
+				pos++
 			} else {
 				parsed = append(parsed, &models.Token{
 					Type:  tokens.Subtraction,
@@ -410,6 +460,18 @@ func (lx *Lexer) parse(s string) ([]*models.Token, error) {
 					},
 				})
 				pos++
+			} else if pos+1 < len(s) && runes[pos+1] == '=' {
+				parsed = append(parsed, &models.Token{
+					Type:  tokens.MultiplicationAssign,
+					Value: "*=",
+					Debug: &models.Debug{
+						Line:   line,
+						Column: col,
+						File:   lx.filename,
+						Near:   lx.near(s, pos, fileLen),
+					},
+				})
+				pos++
 			} else {
 				parsed = append(parsed, &models.Token{
 					Type:  tokens.Multiplication,
@@ -422,6 +484,59 @@ func (lx *Lexer) parse(s string) ([]*models.Token, error) {
 					},
 				})
 			}
+		case '?':
+			if pos+2 < len(s) && runes[pos+1] == '?' && runes[pos+2] == '=' {
+				parsed = append(parsed, &models.Token{
+					Type:  tokens.CoalesceAssign,
+					Value: "??=",
+					Debug: &models.Debug{
+						Line:   line,
+						Column: col,
+						File:   lx.filename,
+						Near:   lx.near(s, pos, fileLen),
+					},
+				})
+				col += 3
+				pos += 2
+			} else if pos+1 < len(s) && runes[pos+1] == '?' {
+				parsed = append(parsed, &models.Token{
+					Type:  tokens.Coalesce,
+					Value: "??",
+					Debug: &models.Debug{
+						Line:   line,
+						Column: col,
+						File:   lx.filename,
+						Near:   lx.near(s, pos, fileLen),
+					},
+				})
+				col += 2
+				pos++
+			} else if pos+1 < len(s) && runes[pos+1] == '.' {
+				parsed = append(parsed, &models.Token{
+					Type:  tokens.OptionalDot,
+					Value: "?.",
+					Debug: &models.Debug{
+						Line:   line,
+						Column: col,
+						File:   lx.filename,
+						Near:   lx.near(s, pos, fileLen),
+					},
+				})
+				col += 2
+				pos++
+			} else {
+				parsed = append(parsed, &models.Token{
+					Type:  tokens.Question,
+					Value: "?",
+					Debug: &models.Debug{
+						Line:   line,
+						Column: col,
+						File:   lx.filename,
+						Near:   lx.near(s, pos, fileLen),
+					},
+				})
+				col++
+			}
 		case '<':
 			if pos+1 < len(s) && runes[pos+1] == '>' {
 				var str strings.Builder
@@ -601,6 +716,8 @@ func (lx *Lexer) getCharIdent(ch rune) tokens.TokenType {
 		return tokens.LeftBracket
 	case ']':
 		return tokens.RightBracket
+	case '@':
+		return tokens.At
 	default:
 		return tokens.Unkown
 	}
@@ -648,6 +765,30 @@ func (lx *Lexer) getIdentType(s string) tokens.TokenType {
 		return tokens.Spin
 	case "error":
 		return tokens.Error
+	case "break":
+		return tokens.Break
+	case "continue":
+		return tokens.Continue
+	case "match":
+		return tokens.Match
+	case "extends":
+		return tokens.Extends
+	case "implements":
+		return tokens.Implements
+	case "interface":
+		return tokens.Interface
+	case "enum":
+		return tokens.Enum
+	case "yield":
+		return tokens.Yield
+	case "try":
+		return tokens.Try
+	case "catch":
+		return tokens.Catch
+	case "finally":
+		return tokens.Finally
+	case "defer":
+		return tokens.Defer
 	default:
 		return tokens.Identifier
 	}
@@ -660,6 +801,11 @@ func (lx *Lexer) near(s string, pos int, fileLen int) string {
 
 	start := max(0, pos-30)
 	end := min(pos+30, fileLen)
+
+	// the code starts on the line of the position, so its lines are numbered from the debug line
+	if i := strings.LastIndexByte(s[start:pos], '\n'); i >= 0 {
+		start += i + 1
+	}
 	substr := s[start:end]
 
 	return strings.TrimSpace(substr)
@@ -53,6 +53,16 @@ func (n *Node) String() string {
 	)
 }
 
+// HasFlag reports whether the node has the given flag
+func (n *Node) HasFlag(flag string) bool {
+	for _, f := range n.Flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
 func (n *Node) ValueString() string {
 	switch n.VariableType {
 	case tokens.StringVariable:
@@ -0,0 +1,102 @@
+package modules
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/flarelang/flare/lang"
+)
+
+type Errors struct{}
+
+func NewErrorsModule() *Errors {
+	return &Errors{}
+}
+
+func (*Errors) Namespace() string {
+	return "errors"
+}
+
+func (*Errors) Objects() map[string]lang.Object {
+	return nil
+}
+
+func (*Errors) Methods() map[string]lang.Method {
+	return map[string]lang.Method{
+		"new": lang.NewFunction(func(args []lang.Object) (lang.Object, error) {
+			message := args[0].Value().(string)
+			return lang.NewError("error", errors.New(message), message, args[0].Debug()), nil
+		}).WithTypeSafeArgs(lang.TypeSafeArg{Name: "message", Type: lang.TString}),
+		"wrap": lang.NewFunction(fnErrorsWrap).
+			WithTypeSafeArgs(lang.TypeSafeArg{Name: "err", Type: lang.TAny}, lang.TypeSafeArg{Name: "message", Type: lang.TString}),
+		"unwrap": lang.NewFunction(func(args []lang.Object) (lang.Object, error) {
+			if cause := lang.ErrorCause(args[0]); cause != nil {
+				return cause, nil
+			}
+			return lang.NewNil("cause", args[0].Debug()), nil
+		}).WithArg("err"),
+		"is": lang.NewFunction(fnErrorsIs).WithArgs([]string{"err", "target"}),
+		"as": lang.NewFunction(fnErrorsAs).WithArgs([]string{"err", "type"}),
+	}
+}
+
+// fnErrorsWrap wraps an error with a message, the wrapped error becomes the cause
+func fnErrorsWrap(args []lang.Object) (lang.Object, error) {
+	err, message := args[0], args[1].Value().(string)
+
+	wrapped := fmt.Errorf("%s: %w", message, lang.Throw(err))
+	return lang.NewError("error", wrapped, message+": "+err.String(), args[1].Debug()), nil
+}
+
+// fnErrorsIs reports whether the error or one of its causes is the target.
+// The target can be an error value or a definition, then the kind of the errors is checked.
+func fnErrorsIs(args []lang.Object) (lang.Object, error) {
+	target := args[1]
+
+	for err := args[0]; err != nil; err = lang.ErrorCause(err) {
+		if errorMatches(err, target) {
+			return lang.NewBool("is", true, args[0].Debug()), nil
+		}
+	}
+
+	return lang.NewBool("is", false, args[0].Debug()), nil
+}
+
+// fnErrorsAs returns the first error in the chain that is an instance of the definition or interface
+func fnErrorsAs(args []lang.Object) (lang.Object, error) {
+	switch args[1].(type) {
+	case *lang.Definition, *lang.Interface:
+	default:
+		return nil, fmt.Errorf("errors.as: type must be a definition or an interface, got %s", lang.TypeOf(args[1]))
+	}
+
+	for err := args[0]; err != nil; err = lang.ErrorCause(err) {
+		if errorMatches(err, args[1]) {
+			return err, nil
+		}
+	}
+
+	return lang.NewNil("as", args[0].Debug()), nil
+}
+
+func errorMatches(err, target lang.Object) bool {
+	inst, isInstance := err.(*lang.Instance)
+
+	switch target := target.(type) {
+	case *lang.Definition:
+		return isInstance && inst.Definition().Extends(target)
+	case *lang.Interface:
+		return isInstance && inst.Definition().Implements(target)
+	case *lang.Error:
+		e, ok := err.(*lang.Error)
+		return ok && errors.Is(e.Err(), target.Err())
+	case *lang.Instance:
+		return isInstance && inst.Same(target)
+	}
+
+	switch target.Type() {
+	case lang.TString, lang.TInt, lang.TFloat, lang.TBool:
+		return err.Type() == target.Type() && err.Value() == target.Value()
+	}
+	return false
+}
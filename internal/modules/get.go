@@ -13,6 +13,7 @@ func Get() []lang.Module {
 	return []lang.Module{
 		NewRandModule(),
 		NewIOModule(),
+		NewErrorsModule(),
 		NewHttpModule(),
 		NewJSONModule(),
 		NewEnv(),
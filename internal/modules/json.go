@@ -105,6 +105,13 @@ func (j *JSON) convertToJSON(obj lang.Object) ([]byte, error) {
 		return json.Marshal(arr)
 	case lang.TNil, lang.TBool, lang.TInt, lang.TFloat, lang.TString:
 		return json.Marshal(obj.Value())
+	case lang.TEnumValue:
+		// enum values are written as their associated value, or as their name without one
+		value := obj.(*lang.EnumValue)
+		if value.Associated() != nil {
+			return j.convertToJSON(value.Associated())
+		}
+		return json.Marshal(value.Member())
 	case lang.TInstance:
 		method := obj.Method("value")
 		if method != nil && len(method.Args()) == 0 {
@@ -1,6 +1,7 @@
 package sqlmodule
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"strings"
@@ -176,12 +177,12 @@ func (db *DB) Value() any {
 func (db *DB) Method(name string) lang.Method {
 	switch name {
 	case "query":
-		return lang.NewFunction(func(args []lang.Object) (lang.Object, error) {
+		return lang.NewContextFunction(func(ctx context.Context, args []lang.Object) (lang.Object, error) {
 			var queryArgs []interface{}
 			for _, arg := range args[1].Value().([]lang.Object) {
 				queryArgs = append(queryArgs, arg.Value())
 			}
-			rows, err := db.db.Query(args[0].Value().(string), queryArgs...)
+			rows, err := db.db.QueryContext(ctx, args[0].Value().(string), queryArgs...)
 			if err != nil {
 				return nil, err
 			}
@@ -222,14 +223,27 @@ func (db *DB) Method(name string) lang.Method {
 			return lang.NewList("result", result, nil), nil
 		}).WithTypeSafeArgs(lang.TypeSafeArg{Name: "query", Type: lang.TString}).WithVariadicArg("values")
 
+	case "rows":
+		return lang.NewContextFunction(func(ctx context.Context, args []lang.Object) (lang.Object, error) {
+			var queryArgs []interface{}
+			for _, arg := range args[1].Value().([]lang.Object) {
+				queryArgs = append(queryArgs, arg.Value())
+			}
+			rows, err := db.db.QueryContext(ctx, args[0].Value().(string), queryArgs...)
+			if err != nil {
+				return nil, err
+			}
+			return newRows(rows)
+		}).WithTypeSafeArgs(lang.TypeSafeArg{Name: "query", Type: lang.TString}).WithVariadicArg("values")
+
 	case "exec":
-		return lang.NewFunction(func(args []lang.Object) (lang.Object, error) {
+		return lang.NewContextFunction(func(ctx context.Context, args []lang.Object) (lang.Object, error) {
 			var queryArgs []interface{}
 			for _, arg := range args[1].Value().([]lang.Object) {
 				queryArgs = append(queryArgs, arg.Value())
 			}
 
-			result, err := db.db.Exec(args[0].Value().(string), queryArgs...)
+			result, err := db.db.ExecContext(ctx, args[0].Value().(string), queryArgs...)
 			if err != nil {
 				return nil, err
 			}
@@ -247,20 +261,20 @@ func (db *DB) Method(name string) lang.Method {
 		}).WithTypeSafeArgs(lang.TypeSafeArg{Name: "query", Type: lang.TString}).WithVariadicArg("values")
 
 	case "queryRow":
-		return lang.NewFunction(func(args []lang.Object) (lang.Object, error) {
+		return lang.NewContextFunction(func(ctx context.Context, args []lang.Object) (lang.Object, error) {
 			var queryArgs []interface{}
 			for _, arg := range args[1].Value().([]lang.Object) {
 				queryArgs = append(queryArgs, arg.Value())
 			}
 
 			// First get column names through a separate query
-			stmt, err := db.db.Prepare(args[0].Value().(string))
+			stmt, err := db.db.PrepareContext(ctx, args[0].Value().(string))
 			if err != nil {
 				return nil, err
 			}
 			defer stmt.Close()
 
-			rows, err := stmt.Query(queryArgs...)
+			rows, err := stmt.QueryContext(ctx, queryArgs...)
 			if err != nil {
 				return nil, err
 			}
@@ -272,7 +286,7 @@ func (db *DB) Method(name string) lang.Method {
 			}
 
 			// Now execute the actual queryRow
-			row := db.db.QueryRow(args[0].Value().(string), queryArgs...)
+			row := db.db.QueryRowContext(ctx, args[0].Value().(string), queryArgs...)
 
 			rowValues := make([]interface{}, len(cols))
 			pointers := make([]interface{}, len(cols))
@@ -303,8 +317,8 @@ func (db *DB) Method(name string) lang.Method {
 		}).WithTypeSafeArgs(lang.TypeSafeArg{Name: "query", Type: lang.TString}).WithVariadicArg("values")
 
 	case "prepare":
-		return lang.NewFunction(func(args []lang.Object) (lang.Object, error) {
-			stmt, err := db.db.Prepare(args[0].Value().(string))
+		return lang.NewContextFunction(func(ctx context.Context, args []lang.Object) (lang.Object, error) {
+			stmt, err := db.db.PrepareContext(ctx, args[0].Value().(string))
 			if err != nil {
 				return nil, err
 			}
@@ -317,8 +331,8 @@ func (db *DB) Method(name string) lang.Method {
 		}).WithTypeSafeArgs(lang.TypeSafeArg{Name: "query", Type: lang.TString})
 
 	case "beginTx":
-		return lang.NewFunction(func(args []lang.Object) (lang.Object, error) {
-			tx, err := db.db.Begin()
+		return lang.NewContextFunction(func(ctx context.Context, args []lang.Object) (lang.Object, error) {
+			tx, err := db.db.BeginTx(ctx, nil)
 			if err != nil {
 				return nil, err
 			}
@@ -338,8 +352,8 @@ func (db *DB) Method(name string) lang.Method {
 		})
 
 	case "ping":
-		return lang.NewFunction(func(args []lang.Object) (lang.Object, error) {
-			err := db.db.Ping()
+		return lang.NewContextFunction(func(ctx context.Context, args []lang.Object) (lang.Object, error) {
+			err := db.db.PingContext(ctx)
 			return lang.NewBool("ping", err == nil, nil), nil
 		})
 
@@ -349,7 +363,7 @@ func (db *DB) Method(name string) lang.Method {
 }
 
 func (db *DB) Methods() []string {
-	return []string{"close", "query", "queryRow", "exec", "prepare", "beginTx", "ping"}
+	return []string{"close", "query", "queryRow", "rows", "exec", "prepare", "beginTx", "ping"}
 }
 
 func (db *DB) Variable(variable string) lang.Object {
@@ -403,13 +417,13 @@ func (s *Statement) Value() any {
 func (s *Statement) Method(name string) lang.Method {
 	switch name {
 	case "exec":
-		return lang.NewFunction(func(args []lang.Object) (lang.Object, error) {
+		return lang.NewContextFunction(func(ctx context.Context, args []lang.Object) (lang.Object, error) {
 			var queryArgs []interface{}
 			for _, arg := range args[0].Value().([]lang.Object) {
 				queryArgs = append(queryArgs, arg.Value())
 			}
 
-			result, err := s.stmt.Exec(queryArgs...)
+			result, err := s.stmt.ExecContext(ctx, queryArgs...)
 			if err != nil {
 				return nil, err
 			}
@@ -427,13 +441,13 @@ func (s *Statement) Method(name string) lang.Method {
 		}).WithVariadicArg("values")
 
 	case "query":
-		return lang.NewFunction(func(args []lang.Object) (lang.Object, error) {
+		return lang.NewContextFunction(func(ctx context.Context, args []lang.Object) (lang.Object, error) {
 			var queryArgs []interface{}
 			for _, arg := range args[0].Value().([]lang.Object) {
 				queryArgs = append(queryArgs, arg.Value())
 			}
 
-			rows, err := s.stmt.Query(queryArgs...)
+			rows, err := s.stmt.QueryContext(ctx, queryArgs...)
 			if err != nil {
 				return nil, err
 			}
@@ -474,15 +488,28 @@ func (s *Statement) Method(name string) lang.Method {
 			return lang.NewList("result", result, nil), nil
 		}).WithVariadicArg("values")
 
+	case "rows":
+		return lang.NewContextFunction(func(ctx context.Context, args []lang.Object) (lang.Object, error) {
+			var queryArgs []interface{}
+			for _, arg := range args[0].Value().([]lang.Object) {
+				queryArgs = append(queryArgs, arg.Value())
+			}
+			rows, err := s.stmt.QueryContext(ctx, queryArgs...)
+			if err != nil {
+				return nil, err
+			}
+			return newRows(rows)
+		}).WithVariadicArg("values")
+
 	case "queryRow":
-		return lang.NewFunction(func(args []lang.Object) (lang.Object, error) {
+		return lang.NewContextFunction(func(ctx context.Context, args []lang.Object) (lang.Object, error) {
 			var queryArgs []interface{}
 			for _, arg := range args[0].Value().([]lang.Object) {
 				queryArgs = append(queryArgs, arg.Value())
 			}
 
 			// Get column names
-			rows, err := s.stmt.Query(queryArgs...)
+			rows, err := s.stmt.QueryContext(ctx, queryArgs...)
 			if err != nil {
 				return nil, err
 			}
@@ -494,7 +521,7 @@ func (s *Statement) Method(name string) lang.Method {
 			}
 
 			// Execute the actual queryRow
-			row := s.stmt.QueryRow(queryArgs...)
+			row := s.stmt.QueryRowContext(ctx, queryArgs...)
 
 			rowValues := make([]interface{}, len(cols))
 			pointers := make([]interface{}, len(cols))
@@ -538,7 +565,7 @@ func (s *Statement) Method(name string) lang.Method {
 }
 
 func (s *Statement) Methods() []string {
-	return []string{"exec", "query", "queryRow", "close"}
+	return []string{"exec", "query", "queryRow", "rows", "close"}
 }
 
 func (s *Statement) Variable(variable string) lang.Object {
@@ -605,13 +632,13 @@ func (t *Transaction) Method(name string) lang.Method {
 		})
 
 	case "exec":
-		return lang.NewFunction(func(args []lang.Object) (lang.Object, error) {
+		return lang.NewContextFunction(func(ctx context.Context, args []lang.Object) (lang.Object, error) {
 			var queryArgs []interface{}
 			for _, arg := range args[1].Value().([]lang.Object) {
 				queryArgs = append(queryArgs, arg.Value())
 			}
 
-			result, err := t.tx.Exec(args[0].Value().(string), queryArgs...)
+			result, err := t.tx.ExecContext(ctx, args[0].Value().(string), queryArgs...)
 			if err != nil {
 				return nil, err
 			}
@@ -629,13 +656,13 @@ func (t *Transaction) Method(name string) lang.Method {
 		}).WithTypeSafeArgs(lang.TypeSafeArg{Name: "query", Type: lang.TString}).WithVariadicArg("values")
 
 	case "query":
-		return lang.NewFunction(func(args []lang.Object) (lang.Object, error) {
+		return lang.NewContextFunction(func(ctx context.Context, args []lang.Object) (lang.Object, error) {
 			var queryArgs []interface{}
 			for _, arg := range args[1].Value().([]lang.Object) {
 				queryArgs = append(queryArgs, arg.Value())
 			}
 
-			rows, err := t.tx.Query(args[0].Value().(string), queryArgs...)
+			rows, err := t.tx.QueryContext(ctx, args[0].Value().(string), queryArgs...)
 			if err != nil {
 				return nil, err
 			}
@@ -677,8 +704,8 @@ func (t *Transaction) Method(name string) lang.Method {
 		}).WithTypeSafeArgs(lang.TypeSafeArg{Name: "query", Type: lang.TString}).WithVariadicArg("values")
 
 	case "prepare":
-		return lang.NewFunction(func(args []lang.Object) (lang.Object, error) {
-			stmt, err := t.tx.Prepare(args[0].Value().(string))
+		return lang.NewContextFunction(func(ctx context.Context, args []lang.Object) (lang.Object, error) {
+			stmt, err := t.tx.PrepareContext(ctx, args[0].Value().(string))
 			if err != nil {
 				return nil, err
 			}
@@ -0,0 +1,128 @@
+package sqlmodule
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/flarelang/flare/lang"
+)
+
+// Rows represents a lazily read query result.
+// Rows are scanned one by one while iterating, and closed when the iteration ends.
+type Rows struct {
+	lang.Base
+	rows *sql.Rows
+	keys []lang.Object
+}
+
+func newRows(rows *sql.Rows) (*Rows, error) {
+	cols, err := rows.Columns()
+	if err != nil {
+		rows.Close()
+		return nil, err
+	}
+
+	keys := make([]lang.Object, len(cols))
+	for i, colName := range cols {
+		keys[i] = lang.NewString("column", colName, nil)
+	}
+
+	return &Rows{
+		Base: lang.NewBase("rows", nil),
+		rows: rows,
+		keys: keys,
+	}, nil
+}
+
+func (r *Rows) Type() lang.ObjType {
+	return lang.TInstance
+}
+
+func (*Rows) TypeString() string {
+	return "sql.rows"
+}
+
+func (r *Rows) Value() any {
+	return r
+}
+
+// Next scans the next row of the result
+func (r *Rows) Next() (lang.Object, bool, error) {
+	if !r.rows.Next() {
+		r.rows.Close()
+		return nil, true, r.rows.Err()
+	}
+
+	rowValues := make([]interface{}, len(r.keys))
+	pointers := make([]interface{}, len(r.keys))
+	for i := range rowValues {
+		pointers[i] = &rowValues[i]
+	}
+	if err := r.rows.Scan(pointers...); err != nil {
+		return nil, false, err
+	}
+
+	values := make([]lang.Object, len(r.keys))
+	for i := range rowValues {
+		value, err := lang.FromValue(rowValues[i])
+		if err != nil {
+			return nil, false, err
+		}
+		values[i] = value
+	}
+
+	return lang.NewArray("row", nil, r.keys, values), false, nil
+}
+
+func (r *Rows) Iterator() lang.Iterator {
+	return r
+}
+
+// Close releases the result, it is called when a loop over the rows ends
+func (r *Rows) Close() error {
+	return r.rows.Close()
+}
+
+func (r *Rows) Method(name string) lang.Method {
+	switch name {
+	case "next":
+		return lang.NextMethod(r)
+	case "close":
+		return lang.NewFunction(func(args []lang.Object) (lang.Object, error) {
+			return nil, r.Close()
+		})
+	default:
+		return nil
+	}
+}
+
+func (r *Rows) Methods() []string {
+	return []string{"next", "close"}
+}
+
+func (r *Rows) Variable(variable string) lang.Object {
+	switch variable {
+	default:
+		return nil
+	case "$addr":
+		return lang.Addr(r)
+	case "columns":
+		return lang.NewList("columns", r.keys, nil)
+	}
+}
+
+func (r *Rows) Variables() []string {
+	return []string{"$addr", "columns"}
+}
+
+func (r *Rows) SetVariable(_ string, _ lang.Object) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (r *Rows) String() string {
+	return fmt.Sprintf("<SQL Rows %s>", lang.Addr(r))
+}
+
+func (r *Rows) Copy() lang.Object {
+	return r
+}
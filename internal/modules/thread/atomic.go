@@ -0,0 +1,114 @@
+package thread
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/flarelang/flare/internal/race"
+	"github.com/flarelang/flare/lang"
+)
+
+// AtomicInt is an integer that can be changed by multiple threads safely
+type AtomicInt struct {
+	lang.Base
+
+	value atomic.Int64
+
+	// clock orders the operations on the value for the race detector
+	clock race.Clock
+}
+
+func NewAtomicInt(value int) *AtomicInt {
+	a := &AtomicInt{
+		Base: lang.NewBase("atomicInt", nil),
+	}
+	a.value.Store(int64(value))
+	return a
+}
+
+func (a *AtomicInt) Type() lang.ObjType {
+	return lang.TInstance
+}
+
+func (a *AtomicInt) TypeString() string {
+	return "thread.atomicInt"
+}
+
+func (a *AtomicInt) Value() any {
+	return a
+}
+
+func (a *AtomicInt) Method(name string) lang.Method {
+	switch name {
+	case "add":
+		// add returns the new value
+		return lang.NewContextFunction(func(ctx context.Context, args []lang.Object) (lang.Object, error) {
+			defer a.sync(ctx)()
+
+			value := a.value.Add(int64(args[0].Value().(int)))
+			return lang.NewInteger("value", int(value), nil), nil
+		}).WithTypeSafeArgs(lang.TypeSafeArg{Name: "delta", Type: lang.TInt})
+	case "load":
+		return lang.NewContextFunction(func(ctx context.Context, _ []lang.Object) (lang.Object, error) {
+			defer a.sync(ctx)()
+
+			return lang.NewInteger("value", int(a.value.Load()), nil), nil
+		})
+	case "store":
+		return lang.NewContextFunction(func(ctx context.Context, args []lang.Object) (lang.Object, error) {
+			defer a.sync(ctx)()
+
+			a.value.Store(int64(args[0].Value().(int)))
+			return nil, nil
+		}).WithTypeSafeArgs(lang.TypeSafeArg{Name: "value", Type: lang.TInt})
+	case "cas":
+		// cas sets the new value if the current value is old, it reports whether the value was swapped
+		return lang.NewContextFunction(func(ctx context.Context, args []lang.Object) (lang.Object, error) {
+			defer a.sync(ctx)()
+
+			old, new := args[0].Value().(int), args[1].Value().(int)
+			return lang.NewBool("swapped", a.value.CompareAndSwap(int64(old), int64(new)), nil), nil
+		}).WithTypeSafeArgs(
+			lang.TypeSafeArg{Name: "old", Type: lang.TInt},
+			lang.TypeSafeArg{Name: "new", Type: lang.TInt},
+		)
+	default:
+		return nil
+	}
+}
+
+// sync orders the operations around an atomic operation like the atomics of Go,
+// it is deferred around the operation: defer a.sync(ctx)()
+func (a *AtomicInt) sync(ctx context.Context) func() {
+	thread := race.FromContext(ctx)
+	thread.Release(&a.clock)
+
+	return func() {
+		thread.Acquire(&a.clock)
+	}
+}
+
+func (a *AtomicInt) Methods() []string {
+	return []string{"add", "load", "store", "cas"}
+}
+
+func (a *AtomicInt) Variable(variable string) lang.Object {
+	return nil
+}
+
+func (a *AtomicInt) Variables() []string {
+	return nil
+}
+
+func (a *AtomicInt) SetVariable(_ string, _ lang.Object) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (a *AtomicInt) String() string {
+	return fmt.Sprintf("%d", a.value.Load())
+}
+
+func (a *AtomicInt) Copy() lang.Object {
+	return a
+}
@@ -0,0 +1,208 @@
+package thread
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/flarelang/flare/internal/race"
+	"github.com/flarelang/flare/lang"
+)
+
+// ErrAwaitTimeout is returned by awaitTimeout when the future does not complete in time
+var ErrAwaitTimeout = fmt.Errorf("future did not complete in time")
+
+// Future is the result of a spawned function, it completes when the function returns
+type Future struct {
+	lang.Base
+
+	done chan struct{}
+
+	value lang.Object
+	err   error
+
+	// clock orders the spawned function before the threads awaiting it for the race detector
+	clock race.Clock
+}
+
+func NewFuture() *Future {
+	return &Future{
+		Base: lang.NewBase("future", nil),
+		done: make(chan struct{}),
+	}
+}
+
+// run runs the spawned function and completes the future with its result
+func (f *Future) run(ctx context.Context, fn lang.Method) {
+	value, err := lang.ExecuteContext(ctx, fn, nil)
+	race.FromContext(ctx).Release(&f.clock)
+	f.resolve(value, err)
+}
+
+// resolve completes the future with the return value or the error of the function
+func (f *Future) resolve(value lang.Object, err error) {
+	if value == nil {
+		value = lang.NewNil("result", nil)
+	}
+
+	f.value, f.err = value, err
+	close(f.done)
+}
+
+// Await blocks until the future is completed
+func (f *Future) Await() (lang.Object, error) {
+	<-f.done
+	return f.value, f.err
+}
+
+// Done returns a channel that is closed when the future is completed
+func (f *Future) Done() <-chan struct{} {
+	return f.done
+}
+
+func (f *Future) isDone() bool {
+	select {
+	case <-f.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (f *Future) Type() lang.ObjType {
+	return lang.TInstance
+}
+
+func (f *Future) TypeString() string {
+	return "thread.future"
+}
+
+func (f *Future) Value() any {
+	return f
+}
+
+func (f *Future) Method(name string) lang.Method {
+	switch name {
+	case "await":
+		return lang.NewContextFunction(func(ctx context.Context, _ []lang.Object) (lang.Object, error) {
+			value, err := f.Await()
+			race.FromContext(ctx).Acquire(&f.clock)
+			return value, err
+		})
+	case "awaitTimeout":
+		return lang.NewContextFunction(func(ctx context.Context, args []lang.Object) (lang.Object, error) {
+			timeout := args[0].Value().(int)
+
+			select {
+			case <-f.done:
+				race.FromContext(ctx).Acquire(&f.clock)
+				return f.value, f.err
+			case <-time.After(time.Duration(timeout) * time.Millisecond):
+				return nil, fmt.Errorf("%w: %dms", ErrAwaitTimeout, timeout)
+			}
+		}).WithTypeSafeArgs(lang.TypeSafeArg{Name: "timeout", Type: lang.TInt})
+	case "done":
+		return lang.NewFunction(func(_ []lang.Object) (lang.Object, error) {
+			return lang.NewBool("done", f.isDone(), nil), nil
+		})
+	case "error":
+		// error returns the error of a completed future, or nil while it is running or if it succeeded
+		return lang.NewFunction(func(_ []lang.Object) (lang.Object, error) {
+			if !f.isDone() || f.err == nil {
+				return lang.NewNil("error", nil), nil
+			}
+			return lang.ErrorValue(f.err), nil
+		})
+	default:
+		return nil
+	}
+}
+
+func (f *Future) Methods() []string {
+	return []string{"await", "awaitTimeout", "done", "error"}
+}
+
+func (f *Future) Variable(variable string) lang.Object {
+	return nil
+}
+
+func (f *Future) Variables() []string {
+	return nil
+}
+
+func (f *Future) SetVariable(_ string, _ lang.Object) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (f *Future) String() string {
+	if f.isDone() {
+		return fmt.Sprintf("<Future %s done>", lang.Addr(f))
+	}
+	return fmt.Sprintf("<Future %s pending>", lang.Addr(f))
+}
+
+func (f *Future) Copy() lang.Object {
+	return f
+}
+
+// futuresOf checks that every element of the list is a future
+func futuresOf(list lang.Object) ([]*Future, error) {
+	items := list.Value().([]lang.Object)
+	futures := make([]*Future, len(items))
+
+	for i, item := range items {
+		f, ok := item.(*Future)
+		if !ok {
+			return nil, fmt.Errorf("expected a list of futures, got %s at index %d", lang.TypeOf(item), i)
+		}
+		futures[i] = f
+	}
+
+	return futures, nil
+}
+
+// awaitAll waits for every future and returns their values in order,
+// it fails with the first error without waiting for the rest
+func awaitAll(ctx context.Context, futures []*Future) (lang.Object, error) {
+	results := make([]lang.Object, len(futures))
+	errs := make(chan error, len(futures))
+
+	for i, f := range futures {
+		go func(i int, f *Future) {
+			value, err := f.Await()
+			results[i] = value
+			errs <- err
+		}(i, f)
+	}
+
+	for range futures {
+		if err := <-errs; err != nil {
+			return nil, err
+		}
+	}
+
+	for _, f := range futures {
+		race.FromContext(ctx).Acquire(&f.clock)
+	}
+
+	return lang.NewList("results", results, nil), nil
+}
+
+// awaitRace returns the value or the error of the first completed future
+func awaitRace(ctx context.Context, futures []*Future) (lang.Object, error) {
+	if len(futures) == 0 {
+		return nil, fmt.Errorf("race expected at least one future")
+	}
+
+	first := make(chan *Future, len(futures))
+	for _, f := range futures {
+		go func(f *Future) {
+			<-f.done
+			first <- f
+		}(f)
+	}
+
+	winner := <-first
+	race.FromContext(ctx).Acquire(&winner.clock)
+	return winner.Await()
+}
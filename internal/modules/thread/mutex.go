@@ -0,0 +1,237 @@
+package thread
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/flarelang/flare/internal/race"
+	"github.com/flarelang/flare/lang"
+)
+
+// ErrNotLocked is returned when unlocking a mutex that is not locked
+var ErrNotLocked = fmt.Errorf("mutex is not locked")
+
+// Mutex is a lock for values shared between threads
+type Mutex struct {
+	lang.Base
+
+	mu sync.Mutex
+	// locked is tracked because unlocking an unlocked sync.Mutex can not be recovered
+	locked atomic.Bool
+
+	// clock orders the critical sections for the race detector
+	clock race.Clock
+}
+
+func NewMutex() *Mutex {
+	return &Mutex{
+		Base: lang.NewBase("mutex", nil),
+	}
+}
+
+func (m *Mutex) Type() lang.ObjType {
+	return lang.TInstance
+}
+
+func (m *Mutex) TypeString() string {
+	return "thread.mutex"
+}
+
+func (m *Mutex) Value() any {
+	return m
+}
+
+func (m *Mutex) Method(name string) lang.Method {
+	switch name {
+	case "lock":
+		return lang.NewContextFunction(func(ctx context.Context, _ []lang.Object) (lang.Object, error) {
+			m.Lock(ctx)
+			return nil, nil
+		})
+	case "unlock":
+		return lang.NewContextFunction(func(ctx context.Context, _ []lang.Object) (lang.Object, error) {
+			return nil, m.Unlock(ctx)
+		})
+	case "withLock":
+		return lang.NewContextFunction(func(ctx context.Context, args []lang.Object) (lang.Object, error) {
+			m.Lock(ctx)
+			defer m.Unlock(ctx)
+
+			return lang.ExecuteContext(ctx, args[0].(*lang.Fn).Fn, nil)
+		}).WithTypeSafeArgs(lang.TypeSafeArg{Name: "method", Type: lang.TFnRef})
+	default:
+		return nil
+	}
+}
+
+func (m *Mutex) Lock(ctx context.Context) {
+	m.mu.Lock()
+	m.locked.Store(true)
+	race.FromContext(ctx).Acquire(&m.clock)
+}
+
+func (m *Mutex) Unlock(ctx context.Context) error {
+	if !m.locked.CompareAndSwap(true, false) {
+		return ErrNotLocked
+	}
+
+	race.FromContext(ctx).Release(&m.clock)
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *Mutex) Methods() []string {
+	return []string{"lock", "unlock", "withLock"}
+}
+
+func (m *Mutex) Variable(variable string) lang.Object {
+	return nil
+}
+
+func (m *Mutex) Variables() []string {
+	return nil
+}
+
+func (m *Mutex) SetVariable(_ string, _ lang.Object) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (m *Mutex) String() string {
+	return fmt.Sprintf("<Mutex %s>", lang.Addr(m))
+}
+
+func (m *Mutex) Copy() lang.Object {
+	return m
+}
+
+// RWMutex is a lock that allows multiple readers or a single writer
+type RWMutex struct {
+	lang.Base
+
+	mu      sync.RWMutex
+	locked  atomic.Bool
+	readers atomic.Int64
+
+	// clock orders the writers, rclock orders the readers before the next writer
+	clock  race.Clock
+	rclock race.Clock
+}
+
+func NewRWMutex() *RWMutex {
+	return &RWMutex{
+		Base: lang.NewBase("rwmutex", nil),
+	}
+}
+
+func (m *RWMutex) Type() lang.ObjType {
+	return lang.TInstance
+}
+
+func (m *RWMutex) TypeString() string {
+	return "thread.rwmutex"
+}
+
+func (m *RWMutex) Value() any {
+	return m
+}
+
+func (m *RWMutex) Method(name string) lang.Method {
+	switch name {
+	case "lock":
+		return lang.NewContextFunction(func(ctx context.Context, _ []lang.Object) (lang.Object, error) {
+			m.Lock(ctx)
+			return nil, nil
+		})
+	case "unlock":
+		return lang.NewContextFunction(func(ctx context.Context, _ []lang.Object) (lang.Object, error) {
+			return nil, m.Unlock(ctx)
+		})
+	case "rlock":
+		return lang.NewContextFunction(func(ctx context.Context, _ []lang.Object) (lang.Object, error) {
+			m.RLock(ctx)
+			return nil, nil
+		})
+	case "runlock":
+		return lang.NewContextFunction(func(ctx context.Context, _ []lang.Object) (lang.Object, error) {
+			return nil, m.RUnlock(ctx)
+		})
+	case "withLock":
+		return lang.NewContextFunction(func(ctx context.Context, args []lang.Object) (lang.Object, error) {
+			m.Lock(ctx)
+			defer m.Unlock(ctx)
+
+			return lang.ExecuteContext(ctx, args[0].(*lang.Fn).Fn, nil)
+		}).WithTypeSafeArgs(lang.TypeSafeArg{Name: "method", Type: lang.TFnRef})
+	case "withRLock":
+		return lang.NewContextFunction(func(ctx context.Context, args []lang.Object) (lang.Object, error) {
+			m.RLock(ctx)
+			defer m.RUnlock(ctx)
+
+			return lang.ExecuteContext(ctx, args[0].(*lang.Fn).Fn, nil)
+		}).WithTypeSafeArgs(lang.TypeSafeArg{Name: "method", Type: lang.TFnRef})
+	default:
+		return nil
+	}
+}
+
+func (m *RWMutex) Lock(ctx context.Context) {
+	m.mu.Lock()
+	m.locked.Store(true)
+
+	thread := race.FromContext(ctx)
+	thread.Acquire(&m.clock)
+	thread.Acquire(&m.rclock)
+}
+
+func (m *RWMutex) Unlock(ctx context.Context) error {
+	if !m.locked.CompareAndSwap(true, false) {
+		return ErrNotLocked
+	}
+
+	race.FromContext(ctx).Release(&m.clock)
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *RWMutex) RLock(ctx context.Context) {
+	m.mu.RLock()
+	m.readers.Add(1)
+	race.FromContext(ctx).Acquire(&m.clock)
+}
+
+func (m *RWMutex) RUnlock(ctx context.Context) error {
+	if m.readers.Add(-1) < 0 {
+		m.readers.Add(1)
+		return fmt.Errorf("%w for reading", ErrNotLocked)
+	}
+
+	race.FromContext(ctx).Release(&m.rclock)
+	m.mu.RUnlock()
+	return nil
+}
+
+func (m *RWMutex) Methods() []string {
+	return []string{"lock", "unlock", "rlock", "runlock", "withLock", "withRLock"}
+}
+
+func (m *RWMutex) Variable(variable string) lang.Object {
+	return nil
+}
+
+func (m *RWMutex) Variables() []string {
+	return nil
+}
+
+func (m *RWMutex) SetVariable(_ string, _ lang.Object) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (m *RWMutex) String() string {
+	return fmt.Sprintf("<RWMutex %s>", lang.Addr(m))
+}
+
+func (m *RWMutex) Copy() lang.Object {
+	return m
+}
@@ -0,0 +1,85 @@
+package thread
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/flarelang/flare/internal/race"
+	"github.com/flarelang/flare/lang"
+)
+
+// Once runs a function only once, even if it is called from multiple threads
+type Once struct {
+	lang.Base
+
+	once sync.Once
+
+	value lang.Object
+	err   error
+
+	// clock orders the first call before the other calls for the race detector
+	clock race.Clock
+}
+
+func NewOnce() *Once {
+	return &Once{
+		Base: lang.NewBase("once", nil),
+	}
+}
+
+func (o *Once) Type() lang.ObjType {
+	return lang.TInstance
+}
+
+func (o *Once) TypeString() string {
+	return "thread.once"
+}
+
+func (o *Once) Value() any {
+	return o
+}
+
+func (o *Once) Method(name string) lang.Method {
+	switch name {
+	case "do":
+		// do returns the result of the first call on every call
+		return lang.NewContextFunction(func(ctx context.Context, args []lang.Object) (lang.Object, error) {
+			thread := race.FromContext(ctx)
+
+			o.once.Do(func() {
+				o.value, o.err = lang.ExecuteContext(ctx, args[0].(*lang.Fn).Fn, nil)
+				thread.Release(&o.clock)
+			})
+			thread.Acquire(&o.clock)
+
+			return o.value, o.err
+		}).WithTypeSafeArgs(lang.TypeSafeArg{Name: "method", Type: lang.TFnRef})
+	default:
+		return nil
+	}
+}
+
+func (o *Once) Methods() []string {
+	return []string{"do"}
+}
+
+func (o *Once) Variable(variable string) lang.Object {
+	return nil
+}
+
+func (o *Once) Variables() []string {
+	return nil
+}
+
+func (o *Once) SetVariable(_ string, _ lang.Object) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (o *Once) String() string {
+	return fmt.Sprintf("<Once %s>", lang.Addr(o))
+}
+
+func (o *Once) Copy() lang.Object {
+	return o
+}
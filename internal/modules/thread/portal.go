@@ -1,17 +1,33 @@
 package thread
 
 import (
+	"context"
 	"fmt"
+	"sync/atomic"
+	"time"
 
+	"github.com/flarelang/flare/internal/race"
 	"github.com/flarelang/flare/lang"
 )
 
+var (
+	// ErrPortalClosed is returned when sending to or closing a closed portal
+	ErrPortalClosed = fmt.Errorf("portal is closed")
+)
+
 type Portal struct {
 	lang.Base
 
 	id uint
 
 	portal chan lang.Object
+	// done is closed by close, the message channel itself is never closed
+	// so blocked senders can not panic
+	done   chan struct{}
+	closed atomic.Bool
+
+	// clock orders the senders before the receivers for the race detector
+	clock race.Clock
 }
 
 func NewPortal(id uint, portalBufferSize int) *Portal {
@@ -19,9 +35,73 @@ func NewPortal(id uint, portalBufferSize int) *Portal {
 		Base:   lang.NewBase("portal", nil),
 		id:     id,
 		portal: make(chan lang.Object, portalBufferSize),
+		done:   make(chan struct{}),
+	}
+}
+
+// Send sends the message to the portal, it blocks while the buffer of the portal is full
+func (p *Portal) Send(msg lang.Object) error {
+	if p.closed.Load() {
+		return p.errClosed()
+	}
+
+	select {
+	case p.portal <- msg:
+		return nil
+	case <-p.done:
+		return p.errClosed()
 	}
 }
 
+// Receive waits for a message, ok is false if the portal is closed and has no more messages
+func (p *Portal) Receive() (lang.Object, bool) {
+	select {
+	case msg := <-p.portal:
+		return msg, true
+	case <-p.done:
+		return p.drain()
+	}
+}
+
+// drain returns a buffered message without blocking
+func (p *Portal) drain() (lang.Object, bool) {
+	select {
+	case msg := <-p.portal:
+		return msg, true
+	default:
+		return nil, false
+	}
+}
+
+// Close closes the portal, the messages in the buffer can still be received
+func (p *Portal) Close() error {
+	if !p.closed.CompareAndSwap(false, true) {
+		return fmt.Errorf("%w: portal %d is already closed", ErrPortalClosed, p.id)
+	}
+
+	close(p.done)
+	return nil
+}
+
+func (p *Portal) errClosed() error {
+	return fmt.Errorf("%w: cannot send to portal %d", ErrPortalClosed, p.id)
+}
+
+// received returns the result of a receive, the value is nil when the portal is closed
+func received(msg lang.Object, ok bool) lang.Object {
+	if msg == nil {
+		msg = lang.NewNil("value", nil)
+	}
+
+	return lang.NewArray("received", nil, []lang.Object{
+		lang.NewString("key", "value", nil),
+		lang.NewString("key", "ok", nil),
+	}, []lang.Object{
+		msg,
+		lang.NewBool("ok", ok, nil),
+	})
+}
+
 func (p *Portal) Type() lang.ObjType {
 	return lang.TInstance
 }
@@ -37,20 +117,57 @@ func (p *Portal) Value() any {
 func (p *Portal) Method(name string) lang.Method {
 	switch name {
 	case "send":
-		return lang.NewFunction(func(args []lang.Object) (lang.Object, error) {
-			p.portal <- args[0]
+		return lang.NewContextFunction(func(ctx context.Context, args []lang.Object) (lang.Object, error) {
+			race.FromContext(ctx).Release(&p.clock)
+			if err := p.Send(args[0]); err != nil {
+				return nil, err
+			}
 			return lang.NewBool("ok", true, args[0].Debug()), nil
 		}).WithArg("message")
 	case "receive":
-		return lang.NewFunction(func(_ []lang.Object) (lang.Object, error) {
+		return lang.NewContextFunction(func(ctx context.Context, _ []lang.Object) (lang.Object, error) {
+			msg, ok := p.Receive()
+			race.FromContext(ctx).Acquire(&p.clock)
+			if !ok {
+				return lang.NewNil("message", nil), nil
+			}
+			return msg, nil
+		})
+	case "tryReceive":
+		// tryReceive returns {value, ok} without blocking, ok is false if there is no message
+		return lang.NewContextFunction(func(ctx context.Context, _ []lang.Object) (lang.Object, error) {
+			msg, ok := p.drain()
+			if ok {
+				race.FromContext(ctx).Acquire(&p.clock)
+			}
+			return received(msg, ok), nil
+		})
+	case "receiveTimeout":
+		// receiveTimeout returns {value, ok}, ok is false if no message arrived in time
+		return lang.NewContextFunction(func(ctx context.Context, args []lang.Object) (lang.Object, error) {
+			timeout := args[0].Value().(int)
+
 			select {
 			case msg := <-p.portal:
-				return msg, nil
+				race.FromContext(ctx).Acquire(&p.clock)
+				return received(msg, true), nil
+			case <-p.done:
+				race.FromContext(ctx).Acquire(&p.clock)
+				return received(p.drain()), nil
+			case <-time.After(time.Duration(timeout) * time.Millisecond):
+				return received(nil, false), nil
 			}
+		}).WithTypeSafeArgs(lang.TypeSafeArg{Name: "timeout", Type: lang.TInt})
+	case "closed":
+		return lang.NewFunction(func(_ []lang.Object) (lang.Object, error) {
+			return lang.NewBool("closed", p.closed.Load(), nil), nil
 		})
 	case "close":
-		return lang.NewFunction(func(_ []lang.Object) (lang.Object, error) {
-			close(p.portal)
+		return lang.NewContextFunction(func(ctx context.Context, _ []lang.Object) (lang.Object, error) {
+			race.FromContext(ctx).Release(&p.clock)
+			if err := p.Close(); err != nil {
+				return nil, err
+			}
 			return lang.NewBool("ok", true, nil), nil
 		})
 	default:
@@ -59,7 +176,7 @@ func (p *Portal) Method(name string) lang.Method {
 }
 
 func (p *Portal) Methods() []string {
-	return []string{"send", "receive"}
+	return []string{"send", "receive", "tryReceive", "receiveTimeout", "closed", "close"}
 }
 
 func (p *Portal) Variable(variable string) lang.Object {
@@ -0,0 +1,161 @@
+package thread
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/flarelang/flare/internal/race"
+	"github.com/flarelang/flare/lang"
+)
+
+// SelectCase is a send or a receive operation on a portal, used by thread.select
+type SelectCase struct {
+	lang.Base
+
+	portal *Portal
+	// send is the message of a send case, nil for receive cases
+	send lang.Object
+}
+
+func NewSelectCase(portal *Portal, send lang.Object) *SelectCase {
+	return &SelectCase{
+		Base:   lang.NewBase("case", nil),
+		portal: portal,
+		send:   send,
+	}
+}
+
+func (c *SelectCase) Type() lang.ObjType {
+	return lang.TInstance
+}
+
+func (c *SelectCase) TypeString() string {
+	return "thread.case"
+}
+
+func (c *SelectCase) Value() any {
+	return c
+}
+
+func (c *SelectCase) Method(name string) lang.Method {
+	return nil
+}
+
+func (c *SelectCase) Methods() []string {
+	return nil
+}
+
+func (c *SelectCase) Variable(variable string) lang.Object {
+	return nil
+}
+
+func (c *SelectCase) Variables() []string {
+	return nil
+}
+
+func (c *SelectCase) SetVariable(_ string, _ lang.Object) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (c *SelectCase) String() string {
+	if c.send != nil {
+		return fmt.Sprintf("<Case send %s>", c.portal)
+	}
+	return fmt.Sprintf("<Case receive %s>", c.portal)
+}
+
+func (c *SelectCase) Copy() lang.Object {
+	return c
+}
+
+// selectResult is the result of thread.select: the index of the chosen case (-1 on timeout),
+// the received value and whether the operation succeeded
+func selectResult(index int, value lang.Object, ok bool) lang.Object {
+	if value == nil {
+		value = lang.NewNil("value", nil)
+	}
+
+	return lang.NewArray("selected", nil, []lang.Object{
+		lang.NewString("key", "index", nil),
+		lang.NewString("key", "value", nil),
+		lang.NewString("key", "ok", nil),
+	}, []lang.Object{
+		lang.NewInteger("index", index, nil),
+		value,
+		lang.NewBool("ok", ok, nil),
+	})
+}
+
+// selectEntry is a channel operation of select, every case waits on its portal and on the close of the portal
+type selectEntry struct {
+	c *SelectCase
+	// index is the position of the case in the list
+	index int
+	// closing is set for the operation on the done channel of the portal
+	closing bool
+}
+
+// selectCases waits until one of the cases can proceed, a portal in the list is a receive case.
+// A negative timeout waits forever.
+func selectCases(ctx context.Context, items []lang.Object, timeout int) (lang.Object, error) {
+	var (
+		thread  = race.FromContext(ctx)
+		cases   = make([]reflect.SelectCase, 0, 2*len(items)+1)
+		entries = make([]selectEntry, 0, 2*len(items))
+	)
+
+	for i, item := range items {
+		var c *SelectCase
+		switch item := item.(type) {
+		case *Portal:
+			c = NewSelectCase(item, nil)
+		case *SelectCase:
+			c = item
+		default:
+			return nil, fmt.Errorf("select expected portals or cases, got %s at index %d", lang.TypeOf(item), i)
+		}
+
+		if c.send != nil {
+			if c.portal.closed.Load() {
+				return nil, c.portal.errClosed()
+			}
+			thread.Release(&c.portal.clock)
+			cases = append(cases, reflect.SelectCase{Dir: reflect.SelectSend, Chan: reflect.ValueOf(c.portal.portal), Send: reflect.ValueOf(c.send)})
+		} else {
+			cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(c.portal.portal)})
+		}
+
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(c.portal.done)})
+		entries = append(entries, selectEntry{c: c, index: i}, selectEntry{c: c, index: i, closing: true})
+	}
+
+	if timeout >= 0 {
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(time.After(time.Duration(timeout) * time.Millisecond))})
+	} else if len(cases) == 0 {
+		return nil, fmt.Errorf("select without cases and timeout would block forever")
+	}
+
+	chosen, recv, _ := reflect.Select(cases)
+	if chosen == len(entries) {
+		return selectResult(-1, nil, false), nil
+	}
+
+	entry := entries[chosen]
+	if entry.c.send == nil {
+		thread.Acquire(&entry.c.portal.clock)
+	}
+
+	switch {
+	case entry.closing && entry.c.send != nil:
+		return nil, entry.c.portal.errClosed()
+	case entry.closing:
+		value, ok := entry.c.portal.drain()
+		return selectResult(entry.index, value, ok), nil
+	case entry.c.send != nil:
+		return selectResult(entry.index, nil, true), nil
+	}
+
+	return selectResult(entry.index, recv.Interface().(lang.Object), true), nil
+}
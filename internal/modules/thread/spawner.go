@@ -1,9 +1,11 @@
 package thread
 
 import (
+	"context"
 	"fmt"
 	"sync"
 
+	"github.com/flarelang/flare/internal/race"
 	"github.com/flarelang/flare/lang"
 )
 
@@ -15,6 +17,9 @@ type Spawner struct {
 	sem chan struct{}
 
 	wg sync.WaitGroup
+
+	// clock orders the spawned functions before close for the race detector
+	clock race.Clock
 }
 
 func NewSpawner(max int) *Spawner {
@@ -40,27 +45,39 @@ func (s *Spawner) Value() any {
 func (s *Spawner) Method(name string) lang.Method {
 	switch name {
 	case "spawn":
-		return lang.NewFunction(func(args []lang.Object) (lang.Object, error) {
+		return lang.NewContextFunction(func(ctx context.Context, args []lang.Object) (lang.Object, error) {
 			fn := args[0].(*lang.Fn).Fn
 			if len(fn.Args()) != 0 {
 				return nil, fmt.Errorf("spawn handler must have 0 arguments, got %d", len(fn.Args()))
 			}
 
+			ctx, err := spawnContext(ctx, args[1])
+			if err != nil {
+				return nil, err
+			}
+
+			future := NewFuture()
+
 			s.wg.Add(1)
 			go func(wg *sync.WaitGroup, sem chan struct{}) {
 				defer wg.Done()
 				defer func() { <-sem }()
 				sem <- struct{}{}
 
-				_, _ = fn.Execute(nil)
+				future.run(ctx, fn)
+				race.FromContext(ctx).Release(&s.clock)
 			}(&s.wg, s.sem)
 
-			return nil, nil
-		}).WithTypeSafeArgs(lang.TypeSafeArg{Name: "method", Type: lang.TFnRef})
+			return future, nil
+		}).WithTypeSafeArgs(
+			lang.TypeSafeArg{Name: "method", Type: lang.TFnRef},
+			lang.TypeSafeArg{Name: "context", Type: lang.TAny},
+		).WithDefault("context", nilContext)
 	case "close":
-		return lang.NewFunction(func(_ []lang.Object) (lang.Object, error) {
+		return lang.NewContextFunction(func(ctx context.Context, _ []lang.Object) (lang.Object, error) {
 			close(s.sem)
 			s.wg.Wait()
+			race.FromContext(ctx).Acquire(&s.clock)
 			return lang.NewBool("ok", true, nil), nil
 		})
 	default:
@@ -1,9 +1,11 @@
 package thread
 
 import (
+	"context"
 	"fmt"
 	"time"
 
+	"github.com/flarelang/flare/internal/race"
 	"github.com/flarelang/flare/lang"
 )
 
@@ -25,10 +27,19 @@ func (*Thread) Objects() map[string]lang.Object {
 
 func (t *Thread) Methods() map[string]lang.Method {
 	return map[string]lang.Method{
-		"sleep": lang.NewFunction(func(args []lang.Object) (lang.Object, error) {
+		"sleep": lang.NewContextFunction(func(ctx context.Context, args []lang.Object) (lang.Object, error) {
 			duration := args[0].Value().(int)
-			time.Sleep(time.Duration(duration) * time.Millisecond)
-			return nil, nil
+
+			// a cancelled context interrupts the sleep
+			timer := time.NewTimer(time.Duration(duration) * time.Millisecond)
+			defer timer.Stop()
+
+			select {
+			case <-timer.C:
+				return nil, nil
+			case <-ctx.Done():
+				return nil, fmt.Errorf("sleep interrupted: %w", ctx.Err())
+			}
 		}).WithTypeSafeArgs(lang.TypeSafeArg{
 			Name: "duration",
 			Type: lang.TInt,
@@ -47,18 +58,86 @@ func (t *Thread) Methods() map[string]lang.Method {
 			t.id++
 			return portal, nil
 		}).WithVariadicArg("buffer"),
-		"spawn": lang.NewFunction(func(args []lang.Object) (lang.Object, error) {
+		"spawn": lang.NewContextFunction(func(ctx context.Context, args []lang.Object) (lang.Object, error) {
 			fn := args[0].(*lang.Fn).Fn
 			if len(fn.Args()) != 0 {
 				return nil, fmt.Errorf("spawn handler must have 0 arguments, got %d", len(fn.Args()))
 			}
 
-			go func() {
-				_, _ = fn.Execute(nil)
-			}()
+			ctx, err := spawnContext(ctx, args[1])
+			if err != nil {
+				return nil, err
+			}
+
+			future := NewFuture()
+			go future.run(ctx, fn)
 
-			return nil, nil
-		}).WithTypeSafeArgs(lang.TypeSafeArg{Name: "method", Type: lang.TFnRef}),
+			return future, nil
+		}).WithTypeSafeArgs(
+			lang.TypeSafeArg{Name: "method", Type: lang.TFnRef},
+			lang.TypeSafeArg{Name: "context", Type: lang.TAny},
+		).WithDefault("context", nilContext),
+		"context": lang.NewContextFunction(func(ctx context.Context, _ []lang.Object) (lang.Object, error) {
+			return lang.NewContext(context.WithCancel(ctx)), nil
+		}),
+		"withTimeout": lang.NewContextFunction(func(ctx context.Context, args []lang.Object) (lang.Object, error) {
+			timeout := time.Duration(args[0].Value().(int)) * time.Millisecond
+			return lang.NewContext(context.WithTimeout(ctx, timeout)), nil
+		}).WithTypeSafeArgs(lang.TypeSafeArg{Name: "timeout", Type: lang.TInt}),
+		"all": lang.NewContextFunction(func(ctx context.Context, args []lang.Object) (lang.Object, error) {
+			futures, err := futuresOf(args[0])
+			if err != nil {
+				return nil, err
+			}
+			return awaitAll(ctx, futures)
+		}).WithTypeSafeArgs(lang.TypeSafeArg{Name: "futures", Type: lang.TList}),
+		"race": lang.NewContextFunction(func(ctx context.Context, args []lang.Object) (lang.Object, error) {
+			futures, err := futuresOf(args[0])
+			if err != nil {
+				return nil, err
+			}
+			return awaitRace(ctx, futures)
+		}).WithTypeSafeArgs(lang.TypeSafeArg{Name: "futures", Type: lang.TList}),
+		"select": lang.NewContextFunction(func(ctx context.Context, args []lang.Object) (lang.Object, error) {
+			return selectCases(ctx, args[0].Value().([]lang.Object), args[1].Value().(int))
+		}).WithTypeSafeArgs(
+			lang.TypeSafeArg{Name: "cases", Type: lang.TList},
+			lang.TypeSafeArg{Name: "timeout", Type: lang.TInt},
+		).WithDefault("timeout", func() (lang.Object, error) {
+			// without a timeout select waits until a case can proceed
+			return lang.NewInteger("timeout", -1, nil), nil
+		}),
+		"receiveCase": lang.NewFunction(func(args []lang.Object) (lang.Object, error) {
+			portal, ok := args[0].(*Portal)
+			if !ok {
+				return nil, fmt.Errorf("receiveCase expected a portal, got %s", lang.TypeOf(args[0]))
+			}
+			return NewSelectCase(portal, nil), nil
+		}).WithArg("portal"),
+		"sendCase": lang.NewFunction(func(args []lang.Object) (lang.Object, error) {
+			portal, ok := args[0].(*Portal)
+			if !ok {
+				return nil, fmt.Errorf("sendCase expected a portal, got %s", lang.TypeOf(args[0]))
+			}
+			return NewSelectCase(portal, args[1]), nil
+		}).WithArgs([]string{"portal", "message"}),
+		"mutex": lang.NewFunction(func(_ []lang.Object) (lang.Object, error) {
+			return NewMutex(), nil
+		}),
+		"rwmutex": lang.NewFunction(func(_ []lang.Object) (lang.Object, error) {
+			return NewRWMutex(), nil
+		}),
+		"waitGroup": lang.NewFunction(func(_ []lang.Object) (lang.Object, error) {
+			return NewWaitGroup(), nil
+		}),
+		"atomicInt": lang.NewFunction(func(args []lang.Object) (lang.Object, error) {
+			return NewAtomicInt(args[0].Value().(int)), nil
+		}).WithTypeSafeArgs(lang.TypeSafeArg{Name: "value", Type: lang.TInt}).WithDefault("value", func() (lang.Object, error) {
+			return lang.NewInteger("value", 0, nil), nil
+		}),
+		"once": lang.NewFunction(func(_ []lang.Object) (lang.Object, error) {
+			return NewOnce(), nil
+		}),
 		"spawner": lang.NewFunction(func(variadicArgs []lang.Object) (lang.Object, error) {
 			spawnerBufferSize := 10
 			args := variadicArgs[0].Value().([]lang.Object)
@@ -75,3 +154,21 @@ func (t *Thread) Methods() map[string]lang.Method {
 		}).WithVariadicArg("buffer"),
 	}
 }
+
+// nilContext is the default context argument of spawn, the spawned function gets the context of the caller
+func nilContext() (lang.Object, error) {
+	return lang.NewNil("context", nil), nil
+}
+
+// spawnContext returns the context of a spawned function, the context given to spawn or the context of the caller.
+// The spawned function runs as a new thread of the race detector.
+func spawnContext(caller context.Context, arg lang.Object) (context.Context, error) {
+	ctx := caller
+	if c, ok := arg.(*lang.Context); ok {
+		ctx = c.Context()
+	} else if arg.Type() != lang.TNil {
+		return nil, fmt.Errorf("spawn expected a context, got %s", lang.TypeOf(arg))
+	}
+
+	return race.NewContext(ctx, race.FromContext(caller).Fork()), nil
+}
@@ -0,0 +1,100 @@
+package thread
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/flarelang/flare/internal/race"
+	"github.com/flarelang/flare/lang"
+)
+
+// WaitGroup waits for a number of threads to finish
+type WaitGroup struct {
+	lang.Base
+
+	wg sync.WaitGroup
+	// count is tracked because a negative sync.WaitGroup counter panics
+	count atomic.Int64
+
+	// clock orders the threads calling done before the threads returning from wait
+	clock race.Clock
+}
+
+func NewWaitGroup() *WaitGroup {
+	return &WaitGroup{
+		Base: lang.NewBase("waitGroup", nil),
+	}
+}
+
+// Add adds delta to the counter, the counter can not become negative
+func (w *WaitGroup) Add(delta int) error {
+	if w.count.Add(int64(delta)) < 0 {
+		w.count.Add(-int64(delta))
+		return fmt.Errorf("wait group counter can not be negative")
+	}
+
+	w.wg.Add(delta)
+	return nil
+}
+
+func (w *WaitGroup) Type() lang.ObjType {
+	return lang.TInstance
+}
+
+func (w *WaitGroup) TypeString() string {
+	return "thread.waitGroup"
+}
+
+func (w *WaitGroup) Value() any {
+	return w
+}
+
+func (w *WaitGroup) Method(name string) lang.Method {
+	switch name {
+	case "add":
+		return lang.NewFunction(func(args []lang.Object) (lang.Object, error) {
+			return nil, w.Add(args[0].Value().(int))
+		}).WithTypeSafeArgs(lang.TypeSafeArg{Name: "delta", Type: lang.TInt}).WithDefault("delta", func() (lang.Object, error) {
+			return lang.NewInteger("delta", 1, nil), nil
+		})
+	case "done":
+		return lang.NewContextFunction(func(ctx context.Context, _ []lang.Object) (lang.Object, error) {
+			race.FromContext(ctx).Release(&w.clock)
+			return nil, w.Add(-1)
+		})
+	case "wait":
+		return lang.NewContextFunction(func(ctx context.Context, _ []lang.Object) (lang.Object, error) {
+			w.wg.Wait()
+			race.FromContext(ctx).Acquire(&w.clock)
+			return nil, nil
+		})
+	default:
+		return nil
+	}
+}
+
+func (w *WaitGroup) Methods() []string {
+	return []string{"add", "done", "wait"}
+}
+
+func (w *WaitGroup) Variable(variable string) lang.Object {
+	return nil
+}
+
+func (w *WaitGroup) Variables() []string {
+	return nil
+}
+
+func (w *WaitGroup) SetVariable(_ string, _ lang.Object) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (w *WaitGroup) String() string {
+	return fmt.Sprintf("<WaitGroup %s>", lang.Addr(w))
+}
+
+func (w *WaitGroup) Copy() lang.Object {
+	return w
+}
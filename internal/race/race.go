@@ -0,0 +1,181 @@
+package race
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/flarelang/flare/internal/errs"
+	"github.com/flarelang/flare/internal/models"
+)
+
+// Detector finds accesses of the same variable from different threads
+// that are not ordered by synchronization, using vector clocks
+type Detector struct {
+	mu sync.Mutex
+
+	// threads is the number of created threads, it is used as the id of the next thread
+	threads int
+	// variables are the last accesses of the tracked variables
+	variables map[Location]*shadow
+
+	reports []Report
+	// reported is the set of reported position pairs, a race is reported only once
+	reported map[[2]models.Debug]bool
+}
+
+// New creates a new race detector
+func New() *Detector {
+	return &Detector{
+		variables: make(map[Location]*shadow),
+		reported:  make(map[[2]models.Debug]bool),
+	}
+}
+
+// Main creates the thread of the main program
+func (d *Detector) Main() *Thread {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.newThread(nil)
+}
+
+// Reports returns the found races in the order they were found
+func (d *Detector) Reports() []Report {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return append([]Report(nil), d.reports...)
+}
+
+func (d *Detector) newThread(parent vectorClock) *Thread {
+	d.threads++
+
+	t := &Thread{
+		id:    d.threads,
+		d:     d,
+		clock: parent.copy(),
+	}
+	t.clock[t.id] = 1
+	return t
+}
+
+// Location identifies a variable: the scope holding it and its name
+type Location struct {
+	Holder any
+	Name   string
+}
+
+// Access is a read or a write of a variable by a thread
+type Access struct {
+	Write  bool
+	Thread int
+	// Name is the name of the variable as it was written in the code
+	Name  string
+	Debug *models.Debug
+
+	epoch uint64
+}
+
+func (a Access) String() string {
+	kind := "read"
+	if a.Write {
+		kind = "write"
+	}
+
+	return fmt.Sprintf("%s of '%s' by thread %d at %s", kind, a.Name, a.Thread, position(a.Debug))
+}
+
+// Report is a race between two accesses of a variable
+type Report struct {
+	Current  Access
+	Previous Access
+}
+
+func (r Report) String() string {
+	var sb strings.Builder
+
+	sb.WriteString("WARNING: DATA RACE\n")
+	writeAccess(&sb, capitalize(r.Current.String()), r.Current.Debug)
+	writeAccess(&sb, "Previous "+r.Previous.String(), r.Previous.Debug)
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// writeAccess writes the access and the code near it, numbered like the code of errors
+func writeAccess(sb *strings.Builder, title string, debug *models.Debug) {
+	sb.WriteString(title + "\n")
+	if debug == nil || debug.Near == "" {
+		return
+	}
+
+	sb.WriteString(errs.Near(debug, func(r io.Reader) string {
+		b, _ := io.ReadAll(r)
+		return string(b)
+	}) + "\n")
+}
+
+func position(debug *models.Debug) string {
+	if debug == nil {
+		return "unknown position"
+	}
+	return fmt.Sprintf("%s:%d:%d", debug.File, debug.Line, debug.Column)
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// shadow holds the last write and the last reads of every thread since that write
+type shadow struct {
+	write *Access
+	reads map[int]*Access
+}
+
+// vectorClock maps thread ids to the last known time of the thread
+type vectorClock map[int]uint64
+
+func (vc vectorClock) copy() vectorClock {
+	c := make(vectorClock, len(vc)+1)
+	for id, t := range vc {
+		c[id] = t
+	}
+	return c
+}
+
+// join merges the other clock into the clock, keeping the later time of every thread
+func (vc vectorClock) join(other vectorClock) {
+	for id, t := range other {
+		if t > vc[id] {
+			vc[id] = t
+		}
+	}
+}
+
+// Clock is the vector clock of a synchronization object like a mutex or a portal.
+// A release stores the time of the thread in it, a later acquire orders the thread after it.
+// The zero value is ready to use.
+type Clock struct {
+	clock vectorClock
+}
+
+type contextKey struct{}
+
+// NewContext returns a context that carries the thread, the context is returned as it is if the thread is nil
+func NewContext(ctx context.Context, t *Thread) context.Context {
+	if t == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, contextKey{}, t)
+}
+
+// FromContext returns the thread of the context, or nil if race detection is disabled
+func FromContext(ctx context.Context) *Thread {
+	t, _ := ctx.Value(contextKey{}).(*Thread)
+	return t
+}
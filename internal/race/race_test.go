@@ -0,0 +1,110 @@
+package race
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flarelang/flare/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var variable = Location{Name: "x"}
+
+func at(line int) *models.Debug {
+	return &models.Debug{File: "main.fl", Line: line, Column: 1}
+}
+
+func TestDetector_SameThread(t *testing.T) {
+	d := New()
+	main := d.Main()
+
+	main.Write(variable, "x", at(1))
+	main.Read(variable, "x", at(2))
+	main.Write(variable, "x", at(3))
+
+	assert.Empty(t, d.Reports(), "accesses of the same thread must never race")
+}
+
+func TestDetector_Unsynchronized(t *testing.T) {
+	d := New()
+	main := d.Main()
+	child := main.Fork()
+
+	child.Write(variable, "x", at(1))
+	main.Write(variable, "x", at(2))
+
+	reports := d.Reports()
+	if assert.Len(t, reports, 1) {
+		assert.True(t, reports[0].Current.Write)
+		assert.Equal(t, main.ID(), reports[0].Current.Thread)
+		assert.Equal(t, at(2), reports[0].Current.Debug, "the report must have the position of the current access")
+		assert.Equal(t, child.ID(), reports[0].Previous.Thread)
+		assert.Equal(t, at(1), reports[0].Previous.Debug, "the report must have the position of the previous access")
+	}
+
+	// the same positions are reported once
+	child.Write(variable, "x", at(1))
+	assert.Len(t, d.Reports(), 1)
+}
+
+func TestDetector_ReadWrite(t *testing.T) {
+	d := New()
+	main := d.Main()
+	a, b := main.Fork(), main.Fork()
+
+	a.Read(variable, "x", at(1))
+	b.Read(variable, "x", at(2))
+	assert.Empty(t, d.Reports(), "reads must not race with reads")
+
+	main.Write(variable, "x", at(3))
+	assert.Len(t, d.Reports(), 2, "a write must race with every unordered read")
+}
+
+func TestDetector_ForkJoin(t *testing.T) {
+	d := New()
+	main := d.Main()
+
+	main.Write(variable, "x", at(1))
+	child := main.Fork()
+	child.Read(variable, "x", at(2))
+	child.Write(variable, "x", at(3))
+
+	main.Join(child)
+	main.Write(variable, "x", at(4))
+
+	assert.Empty(t, d.Reports(), "accesses ordered by fork and join must not race")
+}
+
+func TestDetector_Mutex(t *testing.T) {
+	d := New()
+	main := d.Main()
+	a, b := main.Fork(), main.Fork()
+
+	var mu Clock
+
+	a.Acquire(&mu)
+	a.Write(variable, "x", at(1))
+	a.Release(&mu)
+
+	b.Acquire(&mu)
+	b.Write(variable, "x", at(2))
+	b.Release(&mu)
+
+	assert.Empty(t, d.Reports(), "accesses ordered by a release and an acquire must not race")
+
+	a.Write(variable, "x", at(3))
+	assert.Len(t, d.Reports(), 1, "an access after the release must race with the next holder")
+}
+
+func TestDetector_Disabled(t *testing.T) {
+	var thread *Thread
+
+	assert.NotPanics(t, func() {
+		child := thread.Fork()
+		child.Write(variable, "x", at(1))
+		thread.Join(child)
+		thread.Acquire(&Clock{})
+		thread.Release(&Clock{})
+	}, "a nil thread must do nothing")
+	assert.Nil(t, FromContext(NewContext(context.Background(), thread)))
+}
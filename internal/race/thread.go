@@ -0,0 +1,163 @@
+package race
+
+import "github.com/flarelang/flare/internal/models"
+
+// Thread is a flow of execution, like the main program, a spawned function or a spin iteration.
+// Every method can be called on a nil thread, it does nothing when race detection is disabled.
+type Thread struct {
+	id int
+	d  *Detector
+
+	// clock is guarded by the mutex of the detector
+	clock vectorClock
+}
+
+// ID returns the id of the thread
+func (t *Thread) ID() int {
+	if t == nil {
+		return 0
+	}
+	return t.id
+}
+
+// Fork creates a child thread, everything the thread did before happens before the child
+func (t *Thread) Fork() *Thread {
+	if t == nil {
+		return nil
+	}
+
+	t.d.mu.Lock()
+	defer t.d.mu.Unlock()
+
+	child := t.d.newThread(t.clock)
+	t.clock[t.id]++
+	return child
+}
+
+// Join orders the thread after everything the finished child did
+func (t *Thread) Join(child *Thread) {
+	if t == nil || child == nil {
+		return
+	}
+
+	t.d.mu.Lock()
+	defer t.d.mu.Unlock()
+
+	t.clock.join(child.clock)
+}
+
+// Acquire orders the thread after the releases of the clock, like locking a mutex or receiving a message
+func (t *Thread) Acquire(c *Clock) {
+	if t == nil {
+		return
+	}
+
+	t.d.mu.Lock()
+	defer t.d.mu.Unlock()
+
+	t.clock.join(c.clock)
+}
+
+// Release stores the time of the thread in the clock, like unlocking a mutex or sending a message
+func (t *Thread) Release(c *Clock) {
+	if t == nil {
+		return
+	}
+
+	t.d.mu.Lock()
+	defer t.d.mu.Unlock()
+
+	if c.clock == nil {
+		c.clock = make(vectorClock)
+	}
+	c.clock.join(t.clock)
+	t.clock[t.id]++
+}
+
+// Read records a read of the variable
+func (t *Thread) Read(loc Location, name string, debug *models.Debug) {
+	t.access(loc, name, debug, false)
+}
+
+// Write records a write of the variable
+func (t *Thread) Write(loc Location, name string, debug *models.Debug) {
+	t.access(loc, name, debug, true)
+}
+
+func (t *Thread) access(loc Location, name string, debug *models.Debug, write bool) {
+	if t == nil {
+		return
+	}
+
+	t.d.mu.Lock()
+	defer t.d.mu.Unlock()
+
+	current := &Access{
+		Write:  write,
+		Thread: t.id,
+		Name:   name,
+		Debug:  debug,
+		epoch:  t.clock[t.id],
+	}
+
+	sh, ok := t.d.variables[loc]
+	if !ok {
+		sh = &shadow{reads: make(map[int]*Access)}
+		t.d.variables[loc] = sh
+	}
+
+	// every access races with an unordered write, a write also races with unordered reads
+	if t.concurrent(sh.write) {
+		t.d.report(current, sh.write)
+	}
+
+	if !write {
+		sh.reads[t.id] = current
+		return
+	}
+
+	for _, read := range sh.reads {
+		if t.concurrent(read) {
+			t.d.report(current, read)
+		}
+	}
+
+	sh.write = current
+	sh.reads = make(map[int]*Access)
+}
+
+// concurrent reports whether the access of another thread is not ordered before the thread
+func (t *Thread) concurrent(a *Access) bool {
+	return a != nil && a.Thread != t.id && a.epoch > t.clock[a.Thread]
+}
+
+func (d *Detector) report(current, previous *Access) {
+	// the same two positions are one race, no matter which access came first
+	key := [2]models.Debug{debugOf(current), debugOf(previous)}
+	if before(key[1], key[0]) {
+		key[0], key[1] = key[1], key[0]
+	}
+	if d.reported[key] {
+		return
+	}
+	d.reported[key] = true
+
+	d.reports = append(d.reports, Report{Current: *current, Previous: *previous})
+}
+
+func debugOf(a *Access) models.Debug {
+	if a.Debug == nil {
+		return models.Debug{}
+	}
+	return models.Debug{File: a.Debug.File, Line: a.Debug.Line, Column: a.Debug.Column}
+}
+
+func before(a, b models.Debug) bool {
+	if a.File != b.File {
+		return a.File < b.File
+	}
+	if a.Line != b.Line {
+		return a.Line < b.Line
+	}
+	return a.Column < b.Column
+}
@@ -17,15 +17,28 @@ func (e *Executer) GetMethod(name string) (lang.Method, error) {
 		if first == "this" {
 			def := e.isInsideDefinition(e)
 			if def != nil {
+				// methods are looked up on the most derived definition, so overrides are used
+				if def.derived != nil {
+					return def.mostDerived().GetMethod(strings.Join(append(middle, last), "."))
+				}
 				return e.GetMethod(strings.Join(append(middle, last), "."))
 			}
 
 			return nil, Error(ErrThisOutsideMethod, nil, fnErr(name))
 		}
 
-		exec, err := e.GetNamespaceExecuter(first)
+		if first == "super" {
+			def := e.isInsideDefinition(e)
+			if def == nil || def.inherits == nil {
+				return nil, Error(ErrSuperOutsideDefinition, nil, fnErr(name))
+			}
+
+			return def.inherits.GetMethod(strings.Join(append(middle, last), "."))
+		}
+
+		exec, n, err := e.lookupNamespace(names)
 		if err == nil {
-			ob, err := e.accessNamespace(exec, name, middle, last)
+			ob, err := e.accessNamespace(exec, name, names[n:len(names)-1], last)
 
 			if ob != nil || err != nil {
 				return ob, err
@@ -46,6 +59,10 @@ func (e *Executer) GetMethod(name string) (lang.Method, error) {
 		return method, nil
 	}
 
+	if method := e.inheritedMethod(name); method != nil {
+		return method, nil
+	}
+
 	if obj, ok := e.objects[name]; ok {
 		if obj.Type() == lang.TDefinition {
 			def := obj.(*lang.Definition)
@@ -60,6 +77,13 @@ func (e *Executer) GetMethod(name string) (lang.Method, error) {
 		}
 	}
 
+	if exec, member, ok, err := e.lookupMember(name); ok {
+		if err != nil {
+			return nil, err
+		}
+		return exec.GetMethod(member)
+	}
+
 	if e.parent != nil && (e.scope == ExecuterScopeBlock || e.scope == ExecuterScopeFunction || e.scope == ExecuterScopeDefinition) {
 		return e.parent.GetMethod(name)
 	}
@@ -73,6 +97,10 @@ func (e *Executer) GetMethod(name string) (lang.Method, error) {
 
 // GetVariable gets a variable by name
 func (e *Executer) GetVariable(name string) (lang.Object, error) {
+	if strings.Contains(name, "?.") {
+		return e.getOptionalVariable(name)
+	}
+
 	if strings.Contains(name, ".") {
 		names := strings.Split(name, ".")
 		first := names[0]
@@ -93,9 +121,18 @@ func (e *Executer) GetVariable(name string) (lang.Object, error) {
 			}
 		}
 
-		exec, err := e.GetNamespaceExecuter(first)
+		if first == "super" {
+			def := e.isInsideDefinition(e)
+			if def == nil || def.inherits == nil {
+				return nil, Error(ErrSuperOutsideDefinition, nil, name)
+			}
+
+			return def.inherits.GetVariable(strings.Join(append(middle, last), "."))
+		}
+
+		exec, n, err := e.lookupNamespace(names)
 		if err == nil {
-			ob, err := e.accessObjNamespace(exec, name, middle, last)
+			ob, err := e.accessObjNamespace(exec, name, names[n:len(names)-1], last)
 			if ob != nil || err != nil {
 				return ob, err
 			}
@@ -119,6 +156,22 @@ func (e *Executer) GetVariable(name string) (lang.Object, error) {
 		return lang.NewFn(name, nil, fn), nil
 	}
 
+	if _, obj := e.inheritedObject(name); obj != nil {
+		return obj, nil
+	}
+
+	if exec, member, ok, err := e.lookupMember(name); ok {
+		if err != nil {
+			return nil, err
+		}
+		return exec.GetVariable(member)
+	}
+
+	// GetMethod finds a definition of an enclosing scope as its constructor
+	if def := e.enclosingDefinition(name); def != nil {
+		return def, nil
+	}
+
 	if fn, ok := e.GetMethod(name); ok == nil {
 		return lang.NewFn(name, nil, fn), nil
 	}
@@ -130,27 +183,130 @@ func (e *Executer) GetVariable(name string) (lang.Object, error) {
 	return nil, Error(ErrVariableNotFound, nil, name)
 }
 
+// enclosingDefinition returns the definition of an enclosing scope the name refers to,
+// it stops at a function of the same name like GetMethod does
+func (e *Executer) enclosingDefinition(name string) *lang.Definition {
+	for ex := e; ex.parent != nil; ex = ex.parent {
+		if ex.scope != ExecuterScopeBlock && ex.scope != ExecuterScopeFunction && ex.scope != ExecuterScopeDefinition {
+			return nil
+		}
+
+		p := ex.parent
+		p.mu.RLock()
+		_, isFn := p.functions[name]
+		obj := p.objects[name]
+		p.mu.RUnlock()
+
+		if isFn {
+			return nil
+		}
+
+		if def, ok := obj.(*lang.Definition); ok {
+			return def
+		}
+	}
+	return nil
+}
+
+// getOptionalVariable gets a variable with nil-safe members like `user?.address?.city`,
+// the result is nil when the object before a `?.` is nil or the member after it is missing
+func (e *Executer) getOptionalVariable(name string) (lang.Object, error) {
+	parts := strings.Split(name, "?.")
+
+	obj, err := e.GetVariable(parts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	for _, part := range parts[1:] {
+		for i, member := range strings.Split(part, ".") {
+			if obj.Type() == lang.TNil {
+				if i == 0 {
+					return lang.NewNil(name, nil), nil
+				}
+				return nil, Error(ErrVariableNotFound, nil, name)
+			}
+
+			if err := e.checkInstanceMember(obj, name, member); err != nil {
+				return nil, err
+			}
+
+			next := obj.Variable(member)
+			if next == nil {
+				if i == 0 {
+					return lang.NewNil(name, nil), nil
+				}
+				return nil, Error(ErrVariableNotFound, nil, name)
+			}
+			obj = next
+		}
+	}
+
+	return obj, nil
+}
+
+// getOptionalMethod gets the method of a call with nil-safe members like `user?.greet()`,
+// the method is nil when the call is skipped because its object is nil
+func (e *Executer) getOptionalMethod(name string) (lang.Method, error) {
+	i := strings.LastIndex(name, ".")
+	receiver, member := strings.TrimSuffix(name[:i], "?"), name[i+1:]
+
+	obj, err := e.GetVariable(receiver)
+	if err != nil {
+		return nil, err
+	}
+
+	if obj.Type() == lang.TNil {
+		return nil, nil
+	}
+
+	if err := e.checkInstanceMember(obj, fnErr(name), member); err != nil {
+		return nil, err
+	}
+
+	method := obj.Method(member)
+	if method == nil {
+		return nil, Error(ErrFunctionNotFound, nil, fnErr(name))
+	}
+	return method, nil
+}
+
 // accessNamespace accesses a method in a namespace
 func (e *Executer) accessNamespace(exec *Executer, name string, middle []string, last string) (lang.Method, error) {
 	if len(middle) == 0 {
+		if err := e.checkNamespaceMember(exec, fnErr(name), last); err != nil {
+			return nil, err
+		}
 		return exec.GetMethod(last)
 	}
 
 	first := middle[0]
 	middle = middle[1:]
 
+	if err := e.checkNamespaceMember(exec, fnErr(name), first); err != nil {
+		return nil, err
+	}
+
 	obj, err := exec.GetVariable(first)
 	if err != nil {
 		return nil, Error(ErrFunctionNotFound, nil, fnErr(name))
 	}
 
 	for _, part := range middle {
+		if err := e.checkInstanceMember(obj, fnErr(name), part); err != nil {
+			return nil, err
+		}
+
 		obj = obj.Variable(part)
 		if obj == nil {
 			return nil, Error(ErrFunctionNotFound, nil, fnErr(name))
 		}
 	}
 
+	if err := e.checkInstanceMember(obj, fnErr(name), last); err != nil {
+		return nil, err
+	}
+
 	method := obj.Method(last)
 	if method == nil {
 		return nil, Error(ErrFunctionNotFound, nil, fnErr(name))
@@ -164,12 +320,20 @@ func (e *Executer) accessDefinition(def lang.Object, name string, middle []strin
 	var obj = def
 
 	for _, part := range middle {
+		if err := e.checkInstanceMember(obj, fnErr(name), part); err != nil {
+			return nil, err
+		}
+
 		obj = obj.Variable(part)
 		if obj == nil {
 			return nil, Error(ErrFunctionNotFound, nil, fnErr(name))
 		}
 	}
 
+	if err := e.checkInstanceMember(obj, fnErr(name), last); err != nil {
+		return nil, err
+	}
+
 	method := obj.Method(last)
 	if method == nil {
 		return nil, Error(ErrFunctionNotFound, nil, fnErr(name))
@@ -181,24 +345,39 @@ func (e *Executer) accessDefinition(def lang.Object, name string, middle []strin
 // accessObjNamespace accesses an object in a namespace
 func (e *Executer) accessObjNamespace(exec *Executer, name string, middle []string, last string) (lang.Object, error) {
 	if len(middle) == 0 {
+		if err := e.checkNamespaceMember(exec, name, last); err != nil {
+			return nil, err
+		}
 		return exec.GetVariable(last)
 	}
 
 	first := middle[0]
 	middle = middle[1:]
 
+	if err := e.checkNamespaceMember(exec, name, first); err != nil {
+		return nil, err
+	}
+
 	obj, err := exec.GetVariable(first)
 	if err != nil {
 		return nil, Error(ErrVariableNotFound, nil, name)
 	}
 
 	for _, part := range middle {
+		if err := e.checkInstanceMember(obj, name, part); err != nil {
+			return nil, err
+		}
+
 		obj = obj.Variable(part)
 		if obj == nil {
 			return nil, Error(ErrVariableNotFound, nil, name)
 		}
 	}
 
+	if err := e.checkInstanceMember(obj, name, last); err != nil {
+		return nil, err
+	}
+
 	obj = obj.Variable(last)
 	if obj == nil {
 		return nil, Error(ErrVariableNotFound, nil, name)
@@ -212,12 +391,20 @@ func (e *Executer) accessObjDefinition(def lang.Object, name string, middle []st
 	obj := def
 
 	for _, part := range middle {
+		if err := e.checkInstanceMember(obj, name, part); err != nil {
+			return nil, err
+		}
+
 		obj = obj.Variable(part)
 		if obj == nil {
 			return nil, Error(ErrVariableNotFound, nil, name)
 		}
 	}
 
+	if err := e.checkInstanceMember(obj, name, last); err != nil {
+		return nil, err
+	}
+
 	obj = obj.Variable(last)
 	if obj == nil {
 		return nil, Error(ErrVariableNotFound, nil, name)
@@ -226,6 +413,82 @@ func (e *Executer) accessObjDefinition(def lang.Object, name string, middle []st
 	return obj, nil
 }
 
+// isPrivate reports whether the member is private, names starting with an underscore
+// can only be used inside their namespace, and only through `this` inside their definition
+func isPrivate(name string) bool {
+	return strings.HasPrefix(name, "_")
+}
+
+// checkNamespaceMember returns an error if a private member of the namespace is used from another namespace
+func (e *Executer) checkNamespaceMember(exec *Executer, name string, member string) error {
+	if isPrivate(member) && e.root() != exec {
+		return Error(ErrPrivateMember, nil, name)
+	}
+	return nil
+}
+
+// checkInstanceMember returns an error if a private member of an instance is used from outside of it,
+// the methods of its definition and of the definitions it extends can use it on any instance
+func (e *Executer) checkInstanceMember(obj lang.Object, name string, member string) error {
+	inst, ok := obj.(*lang.Instance)
+	if !ok || !isPrivate(member) {
+		return nil
+	}
+
+	if def := e.isInsideDefinition(e); def != nil && def.definition != nil && inst.Definition().Extends(def.definition) {
+		return nil
+	}
+
+	return Error(ErrPrivateMember, nil, name)
+}
+
+// root returns the executer of the namespace the executer runs in
+func (e *Executer) root() *Executer {
+	ex := e
+	for ex.parent != nil {
+		ex = ex.parent
+	}
+	return ex
+}
+
+// inheritedMethod looks up a method in the parent definition instances
+func (e *Executer) inheritedMethod(name string) lang.Method {
+	for ex := e.inherits; ex != nil; ex = ex.inherits {
+		ex.mu.RLock()
+		method, ok := ex.functions[name]
+		ex.mu.RUnlock()
+
+		if ok {
+			return method
+		}
+	}
+	return nil
+}
+
+// inheritedObject looks up an object in the parent definition instances
+// and returns the executer holding it
+func (e *Executer) inheritedObject(name string) (*Executer, lang.Object) {
+	for ex := e.inherits; ex != nil; ex = ex.inherits {
+		ex.mu.RLock()
+		obj, ok := ex.objects[name]
+		ex.mu.RUnlock()
+
+		if ok {
+			return ex, obj
+		}
+	}
+	return nil, nil
+}
+
+// mostDerived returns the executer of the most derived definition instance
+func (e *Executer) mostDerived() *Executer {
+	ex := e
+	for ex.derived != nil {
+		ex = ex.derived
+	}
+	return ex
+}
+
 func (e *Executer) isInsideDefinition(ex *Executer) *Executer {
 	for ex.parent != nil {
 		ex = ex.parent
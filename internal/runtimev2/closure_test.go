@@ -0,0 +1,153 @@
+package runtimev2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ClosureCounter(t *testing.T) {
+	ex := run(t, `
+		fn makeCounter() {
+			let count = 0;
+			return fn() {
+				count++;
+				return count;
+			};
+		}
+
+		let a = makeCounter();
+		let b = makeCounter();
+		a();
+		a();
+		let first = a();
+		let second = b();
+	`)
+
+	assert.Equal(t, 3, value(t, ex, "first"), "a counter must keep its captured variable between calls")
+	assert.Equal(t, 1, value(t, ex, "second"), "every call of the outer function must create a new variable")
+}
+
+func Test_ClosureAssignsOuterVariable(t *testing.T) {
+	ex := run(t, `
+		let total = 0;
+		fn add(x) {
+			total += x;
+		}
+
+		add(2);
+		add(3);
+
+		let calls = 0;
+		let items = map(fn(item) {
+			calls++;
+			return item * 2;
+		}, [1, 2, 3]);
+	`)
+
+	assert.Equal(t, 5, value(t, ex, "total"), "a declared function must assign the variable of its scope")
+	assert.Equal(t, 3, value(t, ex, "calls"), "a callback must assign the variable of the scope it is created in")
+}
+
+func Test_ClosureSharesVariables(t *testing.T) {
+	ex := run(t, `
+		let x = 1;
+		let getX = fn() => x;
+		let setX = fn(v) {
+			x = v;
+		};
+
+		x = 2;
+		let late = getX();
+
+		setX(3);
+		let shared = getX();
+	`)
+
+	assert.Equal(t, 2, value(t, ex, "late"), "a closure must see the current value of a captured variable")
+	assert.Equal(t, 3, value(t, ex, "shared"), "closures of the same scope must share its variables")
+	assert.Equal(t, 3, value(t, ex, "x"), "a closure must change the variable, not a copy of it")
+}
+
+func Test_ClosureLoopVariable(t *testing.T) {
+	ex := run(t, `
+		let fns = [];
+		for i in 3 {
+			fns.append(fn() => i);
+		}
+
+		let values = [];
+		for f in fns {
+			values.append(f());
+		}
+		let a = values[0];
+		let c = values[2];
+	`)
+
+	assert.Equal(t, 0, value(t, ex, "a"), "every iteration must have its own loop variable")
+	assert.Equal(t, 2, value(t, ex, "c"), "every iteration must have its own loop variable")
+}
+
+func Test_ClosureArgumentsAreCopies(t *testing.T) {
+	ex := run(t, `
+		let n = 1;
+		fn change(n) {
+			n = 5;
+			return n;
+		}
+		let changed = change(n);
+	`)
+
+	assert.Equal(t, 5, value(t, ex, "changed"), "the argument must be changed inside the function")
+	assert.Equal(t, 1, value(t, ex, "n"), "an argument must not change the variable it is passed from")
+}
+
+func Test_ClosureSpawned(t *testing.T) {
+	ex := run(t, `
+		use thread;
+
+		let count = 0;
+		let mu = thread.mutex();
+		let futures = [];
+
+		for i in 4 {
+			futures.append(thread.spawn(fn() {
+				mu.withLock(fn() {
+					count += i;
+				});
+				return i;
+			}));
+		}
+
+		let results = [];
+		for future in futures {
+			results.append(future.await());
+		}
+		let last = results[3];
+	`)
+
+	assert.Equal(t, 6, value(t, ex, "count"), "spawned functions must share the captured variables")
+	assert.Equal(t, 3, value(t, ex, "last"), "every spawned function must capture its own loop variable")
+}
+
+func Test_ClosureMethod(t *testing.T) {
+	ex := run(t, `
+		define Counter {
+			let count = 0;
+
+			fn incrementer() {
+				return fn() {
+					this.count++;
+				};
+			}
+		}
+
+		let counter = Counter();
+		let inc = counter.incrementer();
+		inc();
+		inc();
+		let count = counter.count;
+	`)
+
+	assert.Equal(t, 2, value(t, ex, "count"), "a closure created in a method must change its instance")
+}
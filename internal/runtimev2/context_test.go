@@ -0,0 +1,130 @@
+package runtimev2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ContextCancel(t *testing.T) {
+	ex := run(t, `
+		use thread;
+
+		const ctx = thread.context();
+		const steps = thread.atomicInt(0);
+
+		let before = ctx.cancelled();
+		let beforeErr = ctx.error();
+
+		let future = thread.spawn(fn() {
+			while true {
+				steps.add(1);
+			}
+		}, ctx);
+
+		thread.sleep(20);
+		ctx.cancel();
+
+		error err: future.await();
+		let stopped = steps.load();
+		thread.sleep(20);
+		let later = steps.load();
+
+		let after = ctx.cancelled();
+		let afterErr = ctx.error();
+	`)
+
+	assert.Equal(t, false, value(t, ex, "before"))
+	assert.Nil(t, value(t, ex, "beforeErr"))
+	assert.Contains(t, value(t, ex, "err"), ErrCancelled.Error(), "a cancelled thread must stop with an error")
+	assert.Positive(t, value(t, ex, "stopped"))
+	assert.Equal(t, value(t, ex, "stopped"), value(t, ex, "later"), "a cancelled thread must stop between statements")
+	assert.Equal(t, true, value(t, ex, "after"))
+	assert.NotNil(t, value(t, ex, "afterErr"), "error must return why the context was cancelled")
+
+	_, err := execute(t, `
+		use thread;
+
+		const ctx = thread.context();
+		let future = thread.spawn(fn() {
+			while true {}
+		}, ctx);
+		ctx.cancel();
+		future.await();
+	`)
+	assert.ErrorIs(t, err, ErrCancelled)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	ex = run(t, `
+		use thread;
+
+		const ctx = thread.context();
+		const steps = thread.atomicInt(0);
+		let items = [1];
+
+		let future = thread.spawn(fn() {
+			items.filter(fn(x) {
+				while true {
+					steps.add(1);
+				}
+			});
+		}, ctx);
+
+		thread.sleep(20);
+		ctx.cancel();
+		error err: future.await();
+
+		thread.sleep(20);
+		let stopped = steps.load();
+		thread.sleep(20);
+		let later = steps.load();
+	`)
+	assert.Equal(t, value(t, ex, "stopped"), value(t, ex, "later"), "callbacks of builtins must keep the context of their thread")
+}
+
+func Test_ContextTimeout(t *testing.T) {
+	start := time.Now()
+	_, err := execute(t, `
+		use thread;
+
+		const ctx = thread.withTimeout(20);
+		thread.spawn(fn() {
+			thread.sleep(5000);
+		}, ctx).await();
+	`)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "the timeout must interrupt the sleep")
+	assert.Less(t, time.Since(start), time.Second)
+
+	_, err = execute(t, `
+		use thread;
+
+		const parent = thread.context();
+		const child = parent.withTimeout(5000);
+		parent.cancel();
+		child.run(fn() {
+			let x = 1;
+		});
+	`)
+	assert.ErrorIs(t, err, context.Canceled, "a child context must be cancelled with its parent")
+}
+
+func Test_ContextRun(t *testing.T) {
+	ex := run(t, `
+		use thread;
+
+		const ctx = thread.context();
+		let result = ctx.run(fn() => 42);
+
+		ctx.cancel();
+		fn work() {
+			let x = 1;
+		}
+		error err: ctx.run(work);
+	`)
+
+	assert.Equal(t, 42, value(t, ex, "result"), "run must return the result of the function")
+	assert.Contains(t, value(t, ex, "err"), ErrCancelled.Error(), "run must not run the statements of a cancelled context")
+}
@@ -0,0 +1,79 @@
+package runtimev2
+
+import (
+	"github.com/flarelang/flare/internal/errs"
+	"github.com/flarelang/flare/internal/models"
+	"github.com/flarelang/flare/internal/tokens"
+	"github.com/flarelang/flare/lang"
+)
+
+// decorate applies the decorators of a function or definition declaration to the declared object.
+// The decorator closest to the declaration is applied first, every decorator receives
+// the result of the previous one. A decorator that returns nothing keeps the object.
+func (e *Executer) decorate(node *models.Node, obj lang.Object) (lang.Object, error) {
+	decorators, _ := node.Map["decorators"].([]*models.Node)
+
+	for i := len(decorators) - 1; i >= 0; i-- {
+		decorator := decorators[i]
+
+		method, err := e.decoratorMethod(decorator)
+		if err != nil {
+			return nil, err
+		}
+
+		arg := obj.Copy()
+		if args := method.Args(); len(args) > 0 {
+			arg.Rename(args[0])
+		}
+
+		result, err := lang.ExecuteContext(e.context(), method, []lang.Object{arg})
+		if err != nil {
+			return nil, errs.WithDebug(err, decorator.Debug)
+		}
+
+		if result != nil && result.Type() != lang.TNil {
+			obj = result
+		}
+	}
+
+	return obj, nil
+}
+
+// decoratorMethod returns the function of a decorator, a decorator with arguments
+// like `@route("GET", "/users")` is called first and has to return the function
+func (e *Executer) decoratorMethod(n *models.Node) (lang.Method, error) {
+	var (
+		obj lang.Object
+		err error
+	)
+
+	if n.VariableType == tokens.FunctionCallVariable {
+		obj, err = e.callFunctionFromNode(n)
+	} else {
+		obj, err = e.GetVariable(n.Content)
+	}
+	if err != nil {
+		return nil, errs.WithDebug(err, n.Debug)
+	}
+
+	fn, ok := obj.(*lang.Fn)
+	if !ok {
+		return nil, errs.WithDebug(Error(ErrInvalidDecorator, nil, n.Content), n.Debug)
+	}
+	return fn.Fn, nil
+}
+
+// decorateFunction applies the decorators of a function declaration,
+// the decorators have to return a function
+func (e *Executer) decorateFunction(name string, node *models.Node, method lang.Method) (lang.Method, error) {
+	obj, err := e.decorate(node, lang.NewFn(name, node.Debug, method))
+	if err != nil {
+		return nil, err
+	}
+
+	fn, ok := obj.(*lang.Fn)
+	if !ok {
+		return nil, errs.WithDebug(Error(ErrInvalidDecoratorResult, nil, fnErr(name), gotErr(obj.Type())), node.Debug)
+	}
+	return fn.Fn, nil
+}
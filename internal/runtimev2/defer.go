@@ -0,0 +1,46 @@
+package runtimev2
+
+import (
+	"github.com/flarelang/flare/internal/errs"
+	"github.com/flarelang/flare/internal/models"
+	"go.uber.org/zap"
+)
+
+// handleDefer registers the expression of a defer statement,
+// it is evaluated in the current scope when the enclosing function or file finishes
+func (e *Executer) handleDefer(node *models.Node) {
+	target := e
+	for target.scope == ExecuterScopeBlock && target.parent != nil {
+		target = target.parent
+	}
+
+	target.mu.Lock()
+	defer target.mu.Unlock()
+
+	target.defers = append(target.defers, func() error {
+		_, err := e.evaluateExpression(node)
+		return err
+	})
+}
+
+// runDefers runs the deferred expressions in reverse order.
+// Every deferred expression runs even if one fails, their errors are joined to the original error.
+func (e *Executer) runDefers(err error) error {
+	e.mu.Lock()
+	defers := e.defers
+	e.defers = nil
+	e.mu.Unlock()
+
+	if len(defers) == 0 {
+		return err
+	}
+
+	zap.L().Debug("running deferred expressions", zap.String("executer", e.name), zap.Int("count", len(defers)))
+
+	errors := []error{err}
+	for i := len(defers) - 1; i >= 0; i-- {
+		errors = append(errors, defers[i]())
+	}
+
+	return errs.Join(errors...)
+}
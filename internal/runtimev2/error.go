@@ -31,11 +31,19 @@ var (
 	ErrFunctionRedeclared     = fmt.Errorf("function already declared")
 	ErrNamespaceInUse         = fmt.Errorf("namespace already in use")
 	ErrNamespaceNotFound      = fmt.Errorf("namespace not found")
+	ErrMemberInUse            = fmt.Errorf("name already used by another imported member")
+	ErrPrivateMember          = fmt.Errorf("cannot access private member")
+	ErrImportCycle            = fmt.Errorf("import cycle")
+	ErrTypeMismatch           = fmt.Errorf("type mismatch")
+	ErrUnknownType            = fmt.Errorf("unknown type")
 	ErrFunctionNotFound       = fmt.Errorf("function not found")
 	ErrInvalidArguments       = fmt.Errorf("invalid arguments")
 	ErrIndexOutOfBounds       = fmt.Errorf("index out of bounds")
 	ErrDefinitionReassignment = fmt.Errorf("cannot reassign this definition")
+	ErrInvalidParent          = fmt.Errorf("a definition can only extend a definition")
+	ErrInvalidInterface       = fmt.Errorf("a definition can only implement interfaces")
 	ErrThisOutsideMethod      = fmt.Errorf("'this' cannot be used outside of a method")
+	ErrSuperOutsideDefinition = fmt.Errorf("'super' can only be used inside a definition that extends another one")
 	ErrUnhandledNodeType      = fmt.Errorf("unhandled node type")
 	ErrInvalidIncrementTarget = fmt.Errorf("cannot increment this value")
 	ErrEmptyErrorBlock        = fmt.Errorf("error block has no child nodes")
@@ -47,6 +55,16 @@ var (
 	ErrKeyNotFound            = fmt.Errorf("key not found in array")
 	ErrInvalidObject          = fmt.Errorf("invalid object")
 	ErrInvalidObjectAccess    = fmt.Errorf("invalid object member access")
+	ErrLoopControlOutsideLoop = fmt.Errorf("break and continue can only be used inside a loop")
+	ErrUnsupportedOperator    = fmt.Errorf("unsupported operator")
+	ErrPatternMismatch        = fmt.Errorf("value does not match pattern")
+	ErrMatchNotExhaustive     = fmt.Errorf("match is not exhaustive")
+	ErrInvalidPipe            = fmt.Errorf("invalid pipe")
+	ErrInvalidConditional     = fmt.Errorf("invalid conditional expression")
+	ErrInvalidDecorator       = fmt.Errorf("a decorator must be a function")
+	ErrInvalidDecoratorResult = fmt.Errorf("a decorator of a function must return a function")
+	ErrYieldOutsideGenerator  = fmt.Errorf("yield can only be used inside a generator function")
+	ErrCancelled              = fmt.Errorf("execution cancelled")
 )
 
 func fnErr(name string) string {
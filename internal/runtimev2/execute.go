@@ -16,6 +16,17 @@ func (e *Executer) executeNode(node *models.Node) (lang.Object, error) {
 		return nil, Error(ErrUnhandledNodeType, node.Debug, node.Type)
 	case tokens.Use:
 		using := node.Content
+		if len(node.Args) > 0 {
+			for _, member := range node.Args {
+				as := member.Value.(string)
+				if used, ok := e.usedMembers[as]; ok && used != (usedMember{using, member.Content}) {
+					return nil, errs.WithDebug(Error(ErrMemberInUse, nil, nsErr(used.namespace+"."+used.name, as)), member.Debug)
+				}
+				e.usedMembers[as] = usedMember{namespace: using, name: member.Content}
+			}
+			break
+		}
+
 		as := node.Value.(string)
 		if _, ok := e.usedNamespaces[as]; !ok {
 			//return nil, Error(ErrNamespaceInUse, node.Debug, nsErr(using, as))
@@ -26,6 +37,12 @@ func (e *Executer) executeNode(node *models.Node) (lang.Object, error) {
 		if err != nil {
 			return nil, err
 		}
+		if _, ok := node.Map["decorators"]; ok {
+			method, err = e.decorateFunction(name, node, method)
+			if err != nil {
+				return nil, err
+			}
+		}
 		if _, ok := e.functions[name]; ok {
 			return nil, Error(ErrFunctionRedeclared, node.Debug, fnErr(name))
 		}
@@ -34,6 +51,10 @@ func (e *Executer) executeNode(node *models.Node) (lang.Object, error) {
 		e.functions[name] = method
 		e.mu.Unlock()
 	case tokens.Let, tokens.Const:
+		if node.HasFlag("destructure") {
+			return nil, e.declareDestructured(node)
+		}
+
 		name, object, err := e.createObjectFromNode(node)
 		if err != nil {
 			return nil, err
@@ -41,6 +62,15 @@ func (e *Executer) executeNode(node *models.Node) (lang.Object, error) {
 		if _, ok := e.objects[name]; ok {
 			return nil, Error(ErrVariableNotDeclared, node.Debug, name)
 		}
+		// typed variables can hold nil, they start as nil when declared without a value
+		if typ := typeAnnotation(node, "type"); typ != "" {
+			if object.Type() != lang.TNil {
+				if err := e.checkType(typ, object, "variable "+name); err != nil {
+					return nil, errs.WithDebug(err, node.Debug)
+				}
+			}
+			e.declareType(name, typ)
+		}
 		e.mu.Lock()
 		e.objects[name] = object
 		e.mu.Unlock()
@@ -50,11 +80,26 @@ func (e *Executer) executeNode(node *models.Node) (lang.Object, error) {
 			return nil, errs.WithDebug(err, node.Debug)
 		}
 	case tokens.Assign:
-		err := e.assignObjectFromNode(node)
+		if operator, ok := node.Map["operator"].(string); ok {
+			if err := e.compoundAssignFromNode(node, operator); err != nil {
+				return nil, errs.WithDebug(err, node.Debug)
+			}
+			break
+		}
+
+		assign := e.assignObjectFromNode
+		if node.HasFlag("index") {
+			assign = e.assignIndexFromNode
+		}
+
+		err := assign(node)
 		if err != nil {
 			return nil, errs.WithDebug(err, node.Debug)
 		}
 	case tokens.Increment, tokens.Decrement:
+		e.raceRead(node.Content, node.Debug)
+		e.raceWrite(node.Content, node.Debug)
+
 		v, err := e.GetVariable(node.Content)
 		if err != nil {
 			return nil, errs.WithDebug(err, node.Debug)
@@ -69,9 +114,27 @@ func (e *Executer) executeNode(node *models.Node) (lang.Object, error) {
 			add = -1
 		}
 
-		e.AssignVariable(node.Content, lang.NewInteger(node.Content, v.Value().(int)+add, node.Debug))
-	case tokens.Define:
-		name, object, err := e.createObjectFromDefinitionNode(node)
+		if err := e.AssignVariable(node.Content, lang.NewInteger(node.Content, v.Value().(int)+add, node.Debug)); err != nil {
+			return nil, errs.WithDebug(err, node.Debug)
+		}
+	case tokens.Define, tokens.Interface, tokens.Enum:
+		var (
+			name   string
+			object lang.Object
+			err    error
+		)
+
+		switch node.Type {
+		case tokens.Interface:
+			name, object, err = e.createInterfaceFromNode(node)
+		case tokens.Enum:
+			name, object, err = e.createEnumFromNode(node)
+		default:
+			name, object, err = e.createObjectFromDefinitionNode(node)
+			if _, ok := node.Map["decorators"]; ok && err == nil {
+				object, err = e.decorate(node, object)
+			}
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -83,6 +146,14 @@ func (e *Executer) executeNode(node *models.Node) (lang.Object, error) {
 		e.mu.Unlock()
 	case tokens.Return, tokens.EmptyReturn:
 		return e.handleReturn(node)
+	case tokens.Yield:
+		return e.handleYield(node)
+	case tokens.Try:
+		return e.handleTry(node)
+	case tokens.Throw:
+		return nil, e.handleThrow(node)
+	case tokens.Defer:
+		e.handleDefer(node)
 	case tokens.If:
 		return e.handleIf(node)
 	case tokens.While:
@@ -90,7 +161,11 @@ func (e *Executer) executeNode(node *models.Node) (lang.Object, error) {
 	case tokens.For:
 		return e.handleFor(node)
 	case tokens.Spin:
-		return e.handleSpin(node)
+		return e.handleSpin(node, false)
+	case tokens.Match:
+		return e.handleMatch(node, false)
+	case tokens.Break, tokens.Continue:
+		return newLoopSignal(node), nil
 	case tokens.Error:
 		if len(node.Children) == 0 {
 			return nil, Error(ErrEmptyErrorBlock, node.Debug)
@@ -2,6 +2,8 @@ package runtimev2
 
 import (
 	"bytes"
+	"context"
+	"fmt"
 	"io"
 	"os"
 	"strings"
@@ -31,8 +33,28 @@ type Executer struct {
 	functions map[string]lang.Method
 	// objects is the map of objects
 	objects map[string]lang.Object
+	// types are the type annotations of the typed variables of the scope
+	types map[string]string
 	// usednamespaces is the list of used namespaces
 	usedNamespaces map[string]string
+	// usedMembers are the members imported by a selective use, by their local name
+	usedMembers map[string]usedMember
+
+	// definition is the definition of a definition executer and of its instances
+	definition *lang.Definition
+	// inherits is the executer of the parent definition instance
+	inherits *Executer
+	// derived is the executer of the child definition instance
+	derived *Executer
+
+	// generator is set on the function executer of a `fn*` call
+	generator *generator
+
+	// defers are the deferred expressions of the function or file, run in reverse order
+	defers []func() error
+
+	// ctx is the cancellation context of a function call, blocks use the context of their parent
+	ctx context.Context
 
 	// mu is the mutex
 	mu sync.RWMutex
@@ -46,7 +68,9 @@ func NewExecuter(scope ExecuterScope, r *Runtime, parent *Executer) *Executer {
 		parent:         parent,
 		functions:      make(map[string]lang.Method),
 		objects:        make(map[string]lang.Object),
+		types:          make(map[string]string),
 		usedNamespaces: make(map[string]string),
+		usedMembers:    make(map[string]usedMember),
 	}
 }
 
@@ -57,10 +81,20 @@ func (e *Executer) GetNew() lang.Executer {
 		parent:         e.parent,
 		functions:      make(map[string]lang.Method),
 		objects:        make(map[string]lang.Object),
+		types:          make(map[string]string),
 		usedNamespaces: e.usedNamespaces,
+		usedMembers:    e.usedMembers,
+		definition:     e.definition,
 	}
 }
 
+// Inherit makes the definition executer fall back to the parent definition instance
+func (e *Executer) Inherit(parent lang.Executer) {
+	p := parent.(*Executer)
+	e.inherits = p
+	p.derived = e
+}
+
 // WithName sets the name of the executer
 func (e *Executer) WithName(name string) *Executer {
 	e.name = strings.TrimLeft(e.name+"."+name, ".")
@@ -103,6 +137,9 @@ func (e *Executer) AssignVariable(name string, object lang.Object) error {
 		v, err := e.GetVariable(variable)
 
 		if err == nil {
+			if err := e.checkInstanceMember(v, name, last); err != nil {
+				return err
+			}
 			return v.SetVariable(last, object)
 		}
 	}
@@ -111,9 +148,14 @@ func (e *Executer) AssignVariable(name string, object lang.Object) error {
 	obj, ok := e.objects[name]
 	e.mu.RUnlock()
 	if !ok {
-		if e.parent != nil && e.scope == ExecuterScopeBlock {
+		// functions close over the scope they are created in, so like reads,
+		// assignments of outer variables change the variable where it is declared
+		if e.parent != nil && (e.scope == ExecuterScopeBlock || e.scope == ExecuterScopeFunction) {
 			return e.parent.AssignVariable(name, object)
 		}
+		if ex, _ := e.inheritedObject(name); ex != nil {
+			return ex.AssignVariable(name, object)
+		}
 		return Error(ErrVariableNotDeclared, nil, name)
 	}
 
@@ -125,15 +167,28 @@ func (e *Executer) AssignVariable(name string, object lang.Object) error {
 		return Error(ErrDefinitionReassignment, nil, name)
 	}
 
+	// typed variables can hold nil, like when they are declared without a value
+	if typ, ok := e.declaredType(name); ok && object.Type() != lang.TNil {
+		if err := e.checkType(typ, object, "variable "+name); err != nil {
+			return err
+		}
+	}
+
 	e.mu.Lock()
 	e.objects[name] = object
 	e.mu.Unlock()
 	return nil
 }
 
-// Execute executes the given nodes
+// Execute executes the given nodes, it stops before the next node when the context is cancelled
 func (e *Executer) Execute(nodes []*models.Node) (lang.Object, error) {
+	ctx := e.context()
+
 	for _, node := range nodes {
+		if err := ctx.Err(); err != nil {
+			return nil, Error(fmt.Errorf("%w: %w", ErrCancelled, err), node.Debug)
+		}
+
 		ret, err := e.executeNode(node)
 		if err != nil {
 			return nil, err
@@ -147,6 +202,16 @@ func (e *Executer) Execute(nodes []*models.Node) (lang.Object, error) {
 	return nil, nil
 }
 
+// context returns the cancellation context of the executer
+func (e *Executer) context() context.Context {
+	for ex := e; ex != nil; ex = ex.parent {
+		if ex.ctx != nil {
+			return ex.ctx
+		}
+	}
+	return e.runtime.context()
+}
+
 // Copy creates a copy of the executer
 func (e *Executer) Copy() lang.Executer {
 	ex := &Executer{
@@ -157,6 +222,7 @@ func (e *Executer) Copy() lang.Executer {
 		functions:      e.functions,
 		mu:             sync.RWMutex{},
 		usedNamespaces: e.usedNamespaces,
+		usedMembers:    e.usedMembers,
 	}
 
 	ex.objects = make(map[string]lang.Object)
@@ -164,16 +230,16 @@ func (e *Executer) Copy() lang.Executer {
 		ex.objects[k] = v
 	}
 
+	ex.types = make(map[string]string)
+	for k, v := range e.types {
+		ex.types[k] = v
+	}
+
 	return ex
 }
 
 func (e *Executer) GetNamespaceExecuter(namespace string) (*Executer, error) {
-	nses := e.getUsedNamespaces()
-	if nses == nil {
-		return nil, Error(ErrNamespaceNotFound, nil, namespace)
-	}
-
-	ns, ok := nses[namespace]
+	ns, ok := e.usedNamespace(namespace)
 	if !ok {
 		return nil, Error(ErrNamespaceNotFound, nil, namespace)
 	}
@@ -186,13 +252,64 @@ func (e *Executer) GetNamespaceExecuter(namespace string) (*Executer, error) {
 	return exec, nil
 }
 
-func (e *Executer) getUsedNamespaces() map[string]string {
-	ns := e.usedNamespaces
-	if ns != nil {
-		return ns
+// lookupNamespace finds the longest used namespace the dotted name starts with,
+// it returns the executer of the namespace and the number of name parts it took
+func (e *Executer) lookupNamespace(names []string) (*Executer, int, error) {
+	for i := len(names) - 1; i > 0; i-- {
+		exec, err := e.GetNamespaceExecuter(strings.Join(names[:i], "."))
+		if err == nil {
+			return exec, i, nil
+		}
+	}
+
+	return nil, 0, Error(ErrNamespaceNotFound, nil, names[0])
+}
+
+// usedMember is a member of a namespace imported by `use (member) from namespace`
+type usedMember struct {
+	namespace string
+	name      string
+}
+
+// lookupMember returns the executer of the namespace and the name of the member used by the given local name
+func (e *Executer) lookupMember(name string) (*Executer, string, bool, error) {
+	member, ok := e.usedMembers[name]
+	if !ok {
+		// methods of definitions can use the members imported by the file
+		if e.scope == ExecuterScopeDefinition && e.parent != nil {
+			return e.parent.lookupMember(name)
+		}
+		return nil, "", false, nil
+	}
+
+	exec, err := e.runtime.GetNamespaceExecuter(member.namespace)
+	if err != nil {
+		return nil, "", true, Error(ErrNamespaceNotFound, nil, member.namespace)
+	}
+
+	if err := e.checkNamespaceMember(exec, member.namespace+"."+member.name, member.name); err != nil {
+		return nil, "", true, err
+	}
+
+	return exec, member.name, true, nil
+}
+
+// usedNamespace returns the namespace used by the given name in the scope or its parents,
+// a used namespace can also be reached by its full name, like `app.models.user`
+func (e *Executer) usedNamespace(name string) (string, bool) {
+	for ex := e; ex != nil; ex = ex.parent {
+		if ns, ok := ex.usedNamespaces[name]; ok {
+			return ns, true
+		}
+
+		for _, ns := range ex.usedNamespaces {
+			if ns == name {
+				return ns, true
+			}
+		}
 	}
 
-	return e.parent.getUsedNamespaces()
+	return "", false
 }
 
 func (e *Executer) LoadFile(path string) error {
@@ -3,6 +3,7 @@ package runtimev2
 import (
 	"crypto/md5"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/Knetic/govaluate"
@@ -20,6 +21,23 @@ func (e *Executer) evaluateExpression(n *models.Node) (lang.Object, error) {
 		return nil, Error(ErrExpectedExpression, n.Debug)
 	}
 
+	// the conditional operator binds the weakest, then `??` and then `|>`
+	if i := slices.IndexFunc(n.Children, isTokenOf(tokens.Question)); i != -1 {
+		return e.evaluateConditional(n, i)
+	}
+
+	if slices.ContainsFunc(n.Children, isTokenOf(tokens.Colon)) {
+		return nil, errs.WithDebug(Error(ErrInvalidConditional, nil, "expected '?' before ':'"), n.Debug)
+	}
+
+	if slices.ContainsFunc(n.Children, isTokenOf(tokens.Coalesce)) {
+		return e.evaluateCoalesce(n)
+	}
+
+	if slices.ContainsFunc(n.Children, isPipe) {
+		return e.evaluatePipe(n)
+	}
+
 	var (
 		variableName   string         = n.Content
 		expressionList []string       = make([]string, 0, len(n.Children))
@@ -41,6 +59,8 @@ func (e *Executer) evaluateExpression(n *models.Node) (lang.Object, error) {
 
 		// If the node is a variable reference, get the variable value and add it to the expression list
 		if variableType == tokens.ReferenceVariable {
+			e.raceRead(node.Content, node.Debug)
+
 			obj, err := e.GetVariable(node.Content)
 			if err != nil {
 				return nil, errs.WithDebug(err, n.Debug)
@@ -171,7 +191,7 @@ func (e *Executer) evaluateExpression(n *models.Node) (lang.Object, error) {
 			continue
 		}
 
-		if variableType == tokens.ArrayVariable {
+		if variableType == tokens.ArrayVariable || variableType == tokens.ListVariable || variableType == tokens.MatchVariable || variableType == tokens.SpinVariable || variableType == tokens.ComprehensionVariable {
 			_, obj, err := e.createObjectFromNode(node)
 			if err != nil {
 				return nil, errs.WithDebug(err, n.Debug)
@@ -209,6 +229,10 @@ func (e *Executer) evaluateExpression(n *models.Node) (lang.Object, error) {
 		}
 	}
 
+	if hasInstanceOperand(args) {
+		return e.evaluateOverloaded(expressionList, args, n)
+	}
+
 	value := strings.Join(expressionList, " ")
 	expression, err := govaluate.NewEvaluableExpression(value)
 	if err != nil {
@@ -228,6 +252,11 @@ func (e *Executer) evaluateExpression(n *models.Node) (lang.Object, error) {
 		return nil, Error(err, n.Debug, value)
 	}
 
+	return e.objectFromResult(result, n)
+}
+
+// objectFromResult creates an object from the result of an evaluated expression
+func (e *Executer) objectFromResult(result any, n *models.Node) (lang.Object, error) {
 	if fmt.Sprintf("%T", result) == "float64" && result.(float64) == float64(int64(result.(float64))) {
 		result = int(result.(float64))
 	}
@@ -235,7 +264,7 @@ func (e *Executer) evaluateExpression(n *models.Node) (lang.Object, error) {
 	_, obj, err := e.createObjectFromNode(&models.Node{
 		VariableType: e.getVarType(result),
 		Type:         n.Type,
-		Content:      variableName,
+		Content:      n.Content,
 		Value:        result,
 		Debug:        n.Debug,
 	})
@@ -303,3 +332,237 @@ func newVariableName() string {
 	sum := fmt.Sprintf("var_%x", md5.Sum([]byte(uuid.NewString())))
 	return sum[:10]
 }
+
+// pipeValue is the name the piped value is bound to while the next step of a pipe is called
+const pipeValue = "$pipe"
+
+func isPipe(n *models.Node) bool {
+	return n.Type == tokens.Pipe
+}
+
+// evaluatePipe evaluates a pipe like `items |> filter(isActive) |> map(toName)`,
+// the value of every step is passed as the last positional argument of the next call
+func (e *Executer) evaluatePipe(n *models.Node) (lang.Object, error) {
+	var (
+		steps   [][]*models.Node
+		current []*models.Node
+	)
+
+	for _, child := range n.Children {
+		if isPipe(child) {
+			steps = append(steps, current)
+			current = nil
+			continue
+		}
+		current = append(current, child)
+	}
+	steps = append(steps, current)
+
+	if len(steps[0]) == 0 {
+		return nil, errs.WithDebug(Error(ErrInvalidPipe, nil, "expected a value before '|>'"), n.Debug)
+	}
+
+	value, err := e.evaluateExpression(&models.Node{
+		VariableType: tokens.ExpressionVariable,
+		Content:      n.Content,
+		Children:     steps[0],
+		Debug:        n.Debug,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, step := range steps[1:] {
+		if len(step) != 1 {
+			return nil, errs.WithDebug(Error(ErrInvalidPipe, nil, "expected a function after '|>'"), n.Debug)
+		}
+
+		if value == nil {
+			value = lang.NewNil("nil", n.Debug)
+		}
+
+		// inline functions like `|> fn(list) => list.length` are called with the value
+		if step[0].VariableType == tokens.FunctionVariable {
+			value, err = e.callPipeFunction(step[0], value)
+			if err != nil {
+				return nil, errs.WithDebug(err, n.Debug)
+			}
+			continue
+		}
+
+		call, err := pipeCall(step[0])
+		if err != nil {
+			return nil, errs.WithDebug(err, n.Debug)
+		}
+
+		ex := NewExecuter(ExecuterScopeBlock, e.runtime, e).WithName(e.name + "#pipe")
+		ex.BindObject(pipeValue, value)
+
+		value, err = ex.evaluateExpression(&models.Node{
+			VariableType: tokens.ExpressionVariable,
+			Content:      n.Content,
+			Children:     []*models.Node{call},
+			Debug:        n.Debug,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return value, nil
+}
+
+// callPipeFunction calls an inline function step of a pipe with the piped value
+func (e *Executer) callPipeFunction(n *models.Node, value lang.Object) (lang.Object, error) {
+	name, method, err := e.createMethodFromNode(n)
+	if err != nil {
+		return nil, err
+	}
+
+	if name != "fn" {
+		return nil, Error(ErrNamedInlineFunction, n.Debug)
+	}
+
+	if len(method.Args()) != 1 {
+		return nil, Error(ErrInvalidPipe, nil, "the function after '|>' must have 1 argument")
+	}
+
+	arg := value.Copy()
+	arg.Rename(method.Args()[0])
+
+	return lang.ExecuteContext(e.context(), method, []lang.Object{arg})
+}
+
+// pipeCall returns the call of a pipe step with the piped value added as the last positional argument,
+// a step can be a call like `map(toName)` or the name of a function like `toName`
+func pipeCall(step *models.Node) (*models.Node, error) {
+	arg := &models.Node{
+		Type:         tokens.Identifier,
+		VariableType: tokens.ReferenceVariable,
+		Reference:    true,
+		Content:      pipeValue,
+		Debug:        step.Debug,
+	}
+
+	switch step.VariableType {
+	case tokens.FunctionCallVariable:
+		call := *step
+		i := slices.IndexFunc(step.Args, func(arg *models.Node) bool {
+			return arg.HasFlag("named")
+		})
+		if i == -1 {
+			i = len(step.Args)
+		}
+
+		call.Args = slices.Insert(slices.Clone(step.Args), i, arg)
+		return &call, nil
+	case tokens.ReferenceVariable:
+		if len(step.ObjectAccessors) == 0 {
+			return &models.Node{
+				Type:         tokens.FuncCall,
+				VariableType: tokens.FunctionCallVariable,
+				Content:      step.Content,
+				Args:         []*models.Node{arg},
+				Debug:        step.Debug,
+			}, nil
+		}
+	}
+
+	return nil, Error(ErrInvalidPipe, nil, "expected a function after '|>'")
+}
+
+// isTokenOf returns a function reporting whether a node is the given operator token
+func isTokenOf(typ tokens.TokenType) func(n *models.Node) bool {
+	return func(n *models.Node) bool {
+		return n.Type == typ
+	}
+}
+
+// subExpression returns the expression of a part of the children of n
+func subExpression(n *models.Node, children []*models.Node) *models.Node {
+	return &models.Node{
+		VariableType: tokens.ExpressionVariable,
+		Content:      n.Content,
+		Children:     children,
+		Debug:        n.Debug,
+	}
+}
+
+// evaluateConditional evaluates a conditional like `age >= 18 ? "adult" : "minor"`,
+// q is the position of the '?'. Conditionals nest to the right, so
+// `a ? b : c ? d : e` is the same as `a ? b : (c ? d : e)`.
+func (e *Executer) evaluateConditional(n *models.Node, q int) (lang.Object, error) {
+	colon, depth := -1, 0
+	for i := q + 1; i < len(n.Children) && colon == -1; i++ {
+		switch n.Children[i].Type {
+		case tokens.Question:
+			depth++
+		case tokens.Colon:
+			if depth == 0 {
+				colon = i
+			}
+			depth--
+		}
+	}
+
+	if colon == -1 {
+		return nil, errs.WithDebug(Error(ErrInvalidConditional, nil, "expected ':' after '?'"), n.Debug)
+	}
+
+	condition, then, otherwise := n.Children[:q], n.Children[q+1:colon], n.Children[colon+1:]
+	if len(condition) == 0 || len(then) == 0 || len(otherwise) == 0 {
+		return nil, errs.WithDebug(Error(ErrInvalidConditional, nil, "expected 'condition ? value : value'"), n.Debug)
+	}
+
+	ok, err := e.evaluateExpression(subExpression(n, condition))
+	if err != nil {
+		return nil, err
+	}
+
+	if ok == nil || ok.Type() != lang.TBool {
+		return nil, Error(ErrExpectedBoolean, n.Debug)
+	}
+
+	if ok.Value().(bool) {
+		return e.evaluateExpression(subExpression(n, then))
+	}
+	return e.evaluateExpression(subExpression(n, otherwise))
+}
+
+// evaluateCoalesce evaluates a nil coalescing like `config.port ?? 8080`,
+// the result is the first value that is not nil and the values after it are not evaluated
+func (e *Executer) evaluateCoalesce(n *models.Node) (lang.Object, error) {
+	var (
+		parts   [][]*models.Node
+		current []*models.Node
+	)
+
+	for _, child := range n.Children {
+		if child.Type == tokens.Coalesce {
+			parts = append(parts, current)
+			current = nil
+			continue
+		}
+		current = append(current, child)
+	}
+	parts = append(parts, current)
+
+	var value lang.Object
+	for _, part := range parts {
+		if len(part) == 0 {
+			return nil, errs.WithDebug(Error(ErrUnsupportedOperator, nil, "expected a value on both sides of '??'"), n.Debug)
+		}
+
+		var err error
+		value, err = e.evaluateExpression(subExpression(n, part))
+		if err != nil {
+			return nil, err
+		}
+
+		if value != nil && value.Type() != lang.TNil {
+			return value, nil
+		}
+	}
+
+	return value, nil
+}
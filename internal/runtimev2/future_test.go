@@ -0,0 +1,146 @@
+package runtimev2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/flarelang/flare/internal/modules/thread"
+	"github.com/flarelang/flare/lang"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FutureAwait(t *testing.T) {
+	ex := run(t, `
+		use thread;
+
+		let future = thread.spawn(fn() {
+			thread.sleep(10);
+			return 42;
+		});
+
+		let pending = future.done();
+		let result = future.await();
+		let done = future.done();
+		let err = future.error();
+		let empty = thread.spawn(fn() {}).await();
+	`)
+
+	assert.Equal(t, false, value(t, ex, "pending"))
+	assert.Equal(t, 42, value(t, ex, "result"), "await must return the return value of the function")
+	assert.Equal(t, true, value(t, ex, "done"))
+	assert.Nil(t, value(t, ex, "err"))
+	assert.Nil(t, value(t, ex, "empty"), "a function without a return value must complete with nil")
+}
+
+func Test_FutureError(t *testing.T) {
+	ex := run(t, `
+		use thread;
+
+		let future = thread.spawn(fn() {
+			fail("boom");
+		});
+
+		error err: future.await();
+		let failed = future.error();
+	`)
+
+	assert.Contains(t, value(t, ex, "err"), "boom", "await must fail with the error of the function")
+
+	failed, ok := value(t, ex, "failed").(*lang.Error)
+	if assert.True(t, ok, "error must return an error") {
+		assert.ErrorContains(t, failed.Err(), "boom", "error must return the error of the function")
+	}
+
+	_, err := execute(t, `
+		use thread;
+		thread.spawn(fn() { fail("boom"); }).await();
+	`)
+	assert.ErrorContains(t, err, "boom", "an awaited error must stop the script")
+}
+
+func Test_FutureAwaitTimeout(t *testing.T) {
+	ex := run(t, `
+		use thread;
+
+		let fast = thread.spawn(fn() => 1);
+		let result = fast.awaitTimeout(1000);
+	`)
+	assert.Equal(t, 1, value(t, ex, "result"))
+
+	_, err := execute(t, `
+		use thread;
+		thread.spawn(fn() { thread.sleep(1000); }).awaitTimeout(10);
+	`)
+	assert.ErrorIs(t, err, thread.ErrAwaitTimeout)
+}
+
+func Test_FutureAll(t *testing.T) {
+	ex := run(t, `
+		use thread;
+
+		let futures = [];
+		for i in 3 {
+			futures.append(thread.spawn(fn() {
+				thread.sleep(30 - i * 10);
+				return i;
+			}));
+		}
+		let results = thread.all(futures);
+		let first = results[0];
+		let last = results[2];
+		let none = thread.all([]);
+	`)
+
+	assert.Equal(t, 0, value(t, ex, "first"), "all must return the values in the order of the futures")
+	assert.Equal(t, 2, value(t, ex, "last"))
+	assert.Empty(t, value(t, ex, "none"))
+
+	start := time.Now()
+	_, err := execute(t, `
+		use thread;
+
+		let slow = thread.spawn(fn() { thread.sleep(2000); });
+		let failing = thread.spawn(fn() { fail("all failed"); });
+		thread.all([slow, failing]);
+	`)
+	assert.ErrorContains(t, err, "all failed", "all must fail with the error of a future")
+	assert.Less(t, time.Since(start), time.Second, "all must not wait for the rest after an error")
+
+	_, err = execute(t, `
+		use thread;
+		thread.all([1, 2]);
+	`)
+	assert.ErrorContains(t, err, "expected a list of futures")
+}
+
+func Test_FutureRace(t *testing.T) {
+	ex := run(t, `
+		use thread;
+
+		let slow = thread.spawn(fn() {
+			thread.sleep(1000);
+			return "slow";
+		});
+		let fast = thread.spawn(fn() => "fast");
+		let winner = thread.race([slow, fast]);
+	`)
+	assert.Equal(t, "fast", value(t, ex, "winner"), "race must return the value of the first completed future")
+
+	_, err := execute(t, `
+		use thread;
+
+		let slow = thread.spawn(fn() {
+			thread.sleep(1000);
+			return "slow";
+		});
+		let failing = thread.spawn(fn() { fail("race failed"); });
+		thread.race([slow, failing]);
+	`)
+	assert.ErrorContains(t, err, "race failed", "race must fail with the error of the first completed future")
+
+	_, err = execute(t, `
+		use thread;
+		thread.race([]);
+	`)
+	assert.ErrorContains(t, err, "at least one future")
+}
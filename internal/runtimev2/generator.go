@@ -0,0 +1,247 @@
+package runtimev2
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/flarelang/flare/internal/models"
+	"github.com/flarelang/flare/lang"
+	"go.uber.org/zap"
+)
+
+// generator is the object returned by `fn*` functions.
+// The function body runs in its own goroutine and is suspended at every yield,
+// until the next value is requested by a loop or the `next` method.
+// A suspended body also exits when the context of its executer is cancelled,
+// so a generator that is dropped before its end does not outlive its thread.
+type generator struct {
+	lang.Base
+
+	ex    *Executer
+	nodes []*models.Node
+
+	// values receives the yielded values and the end of the generator
+	values chan generatorStep
+	// resume continues the body after a yield
+	resume chan struct{}
+	// stop tells a suspended body to exit
+	stop chan struct{}
+	// exited is closed when the goroutine of the body returns
+	exited chan struct{}
+	// last is the final step of the body, it is read by Next when the step was not received
+	last generatorStep
+
+	mu       sync.Mutex
+	started  bool
+	finished bool
+	stopOnce sync.Once
+}
+
+// generatorStep is a value produced by the generator body
+type generatorStep struct {
+	value lang.Object
+	err   error
+	done  bool
+}
+
+func newGenerator(name string, ex *Executer, nodes []*models.Node, debug *models.Debug) *generator {
+	g := &generator{
+		Base:   lang.NewBase(name, debug),
+		ex:     ex,
+		nodes:  nodes,
+		values: make(chan generatorStep),
+		resume: make(chan struct{}),
+		stop:   make(chan struct{}),
+		exited: make(chan struct{}),
+	}
+
+	ex.generator = g
+	return g
+}
+
+// run executes the generator body, it is started by the first Next call
+func (g *generator) run() {
+	// a closed generator exits in yield, the deferred expressions still run
+	finished := false
+	defer func() {
+		if !finished {
+			_ = g.ex.runDefers(nil)
+		}
+		close(g.exited)
+	}()
+
+	ret, err := g.ex.Execute(g.nodes)
+	finished = true
+
+	err = g.ex.runDefers(err)
+	if err == nil {
+		err = escapedLoopSignal(ret)
+	}
+
+	g.last = generatorStep{err: err, done: true}
+
+	select {
+	case g.values <- g.last:
+	case <-g.stop:
+	case <-g.ex.context().Done():
+	}
+}
+
+// yield hands the value to the consumer and suspends the body until it is resumed.
+// A closed generator exits the goroutine of the body,
+// a cancelled context resumes the body so it fails at its next statement.
+func (g *generator) yield(value lang.Object) {
+	done := g.ex.context().Done()
+
+	select {
+	case g.values <- generatorStep{value: value}:
+	case <-g.stop:
+		runtime.Goexit()
+	case <-done:
+		return
+	}
+
+	select {
+	case <-g.resume:
+	case <-g.stop:
+		runtime.Goexit()
+	case <-done:
+	}
+}
+
+// Next resumes the body until the next yield or the end of the function
+func (g *generator) Next() (lang.Object, bool, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.finished {
+		return nil, true, nil
+	}
+
+	if !g.started {
+		g.started = true
+		go g.run()
+	} else {
+		select {
+		case g.resume <- struct{}{}:
+		case <-g.exited:
+		}
+	}
+
+	var step generatorStep
+	select {
+	case step = <-g.values:
+	case <-g.exited:
+		// the body stopped on a cancelled context without a consumer
+		step = g.last
+	}
+
+	if step.done {
+		g.finished = true
+		g.stopOnce.Do(func() { close(g.stop) })
+	}
+
+	zap.L().Debug("generator step", zap.Any("value", step.value), zap.Bool("done", step.done))
+
+	return step.value, step.done, step.err
+}
+
+// Close stops a suspended generator, the rest of the body is not executed
+func (g *generator) Close() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.finished = true
+	g.stopOnce.Do(func() { close(g.stop) })
+
+	// wait for the body, so its deferred expressions run before the loop continues
+	if g.started {
+		<-g.exited
+	}
+	return nil
+}
+
+func (g *generator) Iterator() lang.Iterator {
+	return g
+}
+
+func (g *generator) Type() lang.ObjType {
+	return lang.TInstance
+}
+
+func (*generator) TypeString() string {
+	return "generator"
+}
+
+func (g *generator) Value() any {
+	return g
+}
+
+func (g *generator) Method(name string) lang.Method {
+	switch name {
+	case "next":
+		return lang.NextMethod(g)
+	case "close":
+		return lang.NewFunction(func(args []lang.Object) (lang.Object, error) {
+			return nil, g.Close()
+		})
+	default:
+		return nil
+	}
+}
+
+func (g *generator) Methods() []string {
+	return []string{"next", "close"}
+}
+
+func (g *generator) Variable(variable string) lang.Object {
+	switch variable {
+	default:
+		return nil
+	case "$addr":
+		return lang.Addr(g)
+	}
+}
+
+func (g *generator) Variables() []string {
+	return []string{"$addr"}
+}
+
+func (g *generator) SetVariable(_ string, _ lang.Object) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (g *generator) String() string {
+	return fmt.Sprintf("<Generator %s>", lang.Addr(g))
+}
+
+func (g *generator) Copy() lang.Object {
+	return g
+}
+
+// handleYield passes the value of a yield statement to the generator of the function
+func (e *Executer) handleYield(node *models.Node) (lang.Object, error) {
+	g := e.currentGenerator()
+	if g == nil {
+		return nil, Error(ErrYieldOutsideGenerator, node.Debug)
+	}
+
+	value, err := e.evaluateExpression(node)
+	if err != nil {
+		return nil, err
+	}
+
+	g.yield(value.Copy())
+	return nil, nil
+}
+
+// currentGenerator returns the generator of the enclosing function, if any
+func (e *Executer) currentGenerator() *generator {
+	for ex := e; ex != nil; ex = ex.parent {
+		if ex.scope == ExecuterScopeFunction {
+			return ex.generator
+		}
+	}
+	return nil
+}
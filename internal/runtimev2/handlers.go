@@ -1,11 +1,14 @@
 package runtimev2
 
 import (
-	"strings"
+	"errors"
+	"io"
 	"sync"
+	"sync/atomic"
 
 	"github.com/flarelang/flare/internal/errs"
 	"github.com/flarelang/flare/internal/models"
+	"github.com/flarelang/flare/internal/race"
 	"github.com/flarelang/flare/internal/tokens"
 	"github.com/flarelang/flare/lang"
 	"go.uber.org/zap"
@@ -114,8 +117,8 @@ func (e *Executer) handleWhile(node *models.Node) (lang.Object, error) {
 			break
 		}
 
-		ret, err := ex.Execute(node.Children)
-		if ret != nil || err != nil {
+		stop, ret, err := loopResult(ex.Execute(node.Children))
+		if stop {
 			return ret, err
 		}
 	}
@@ -131,54 +134,42 @@ func (e *Executer) handleFor(node *models.Node) (lang.Object, error) {
 
 	zap.L().Debug("handling for loop", zap.String("name", name), zap.Any("iterable", iterable))
 
-	switch iterable.Type() {
-	default:
+	it, ok := lang.IteratorOf(iterable)
+	if !ok {
 		return nil, Error(ErrExpectedIterable, node.Debug, gotErr(iterable.Type()))
-	case lang.TList:
-		for i := range iterable.Value().([]lang.Object) {
-			item := iterable.Value().([]lang.Object)[i]
+	}
 
-			exec := NewExecuter(ExecuterScopeBlock, ex.runtime, ex)
-			exec.mu.Lock()
-			exec.objects[name] = item.Copy()
-			exec.mu.Unlock()
+	// iterators holding resources, like generators and query results, are released when the loop ends
+	if closer, ok := it.(io.Closer); ok {
+		defer closer.Close()
+	}
 
-			ret, err := exec.Execute(node.Children)
-			if ret != nil || err != nil {
-				return ret, err
-			}
+	for {
+		item, done, err := it.Next()
+		if err != nil {
+			return nil, errs.WithDebug(err, node.Debug)
+		}
+		if done {
+			break
 		}
-	case lang.TString:
-		str := strings.Split(iterable.Value().(string), "")
-		for _, item := range str {
-			exec := NewExecuter(ExecuterScopeBlock, ex.runtime, ex)
-			exec.mu.Lock()
-			exec.objects[name] = lang.NewString(name, string(item), node.Debug)
-			exec.mu.Unlock()
 
-			ret, err := exec.Execute(node.Children)
-			if ret != nil || err != nil {
-				return ret, err
-			}
+		exec := NewExecuter(ExecuterScopeBlock, ex.runtime, ex)
+		if err := exec.bindLoopItem(node, name, item.Copy()); err != nil {
+			return nil, err
 		}
-	case lang.TInt:
-		for item := 0; item < iterable.Value().(int); item++ {
-			exec := NewExecuter(ExecuterScopeBlock, ex.runtime, ex)
-			exec.mu.Lock()
-			exec.objects[name] = lang.NewInteger(name, item, node.Debug)
-			exec.mu.Unlock()
 
-			ret, err := exec.Execute(node.Children)
-			if ret != nil || err != nil {
-				return ret, err
-			}
+		stop, ret, err := loopResult(exec.Execute(node.Children))
+		if stop {
+			return ret, err
 		}
 	}
 
 	return nil, nil
 }
 
-func (e *Executer) handleSpin(node *models.Node) (lang.Object, error) {
+// handleSpin runs the iterations of a spin loop concurrently, at most limit at a time if a limit is given.
+// Every error is collected, and in expression mode the results are returned in the order of the items.
+func (e *Executer) handleSpin(node *models.Node, expression bool) (lang.Object, error) {
 	name, ex, iterable, err := e.initFor(node)
 	if err != nil {
 		return nil, err
@@ -186,110 +177,334 @@ func (e *Executer) handleSpin(node *models.Node) (lang.Object, error) {
 
 	zap.L().Debug("handling spin loop", zap.String("name", name), zap.Any("iterable", iterable))
 
-	switch iterable.Type() {
-	default:
+	limit, err := e.spinLimit(node)
+	if err != nil {
+		return nil, err
+	}
+
+	it, ok := lang.IteratorOf(iterable)
+	if !ok {
 		return nil, Error(ErrExpectedIterable, node.Debug, gotErr(iterable.Type()))
-	case lang.TList:
-		var wg sync.WaitGroup
+	}
 
-		iterableValue := iterable.Value().([]lang.Object)
-		wg.Add(len(iterableValue))
+	// iterators holding resources, like generators and query results, are released when the loop ends
+	if closer, ok := it.(io.Closer); ok {
+		defer closer.Close()
+	}
 
-		var err error
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		stopped atomic.Bool
+		// slots limits the number of running iterations, nil means no limit
+		slots chan struct{}
+		// errList holds the error of every item, so they are joined in the order of the items
+		errList []error
+		// iterErr is the error of the iterator, it stops scheduling the rest
+		iterErr error
+		results []lang.Object
+		// threads are the race detector threads of the iterations
+		thread  = race.FromContext(ex.context())
+		threads []*race.Thread
+	)
+
+	if limit > 0 {
+		slots = make(chan struct{}, limit)
+	}
 
-		for i := range iterableValue {
-			go func() {
-				item := iterable.Value().([]lang.Object)[i]
+	for i := 0; ; i++ {
+		// a break in one of the iterations stops scheduling the rest
+		if stopped.Load() {
+			break
+		}
 
-				exec := NewExecuter(ExecuterScopeBlock, ex.runtime, ex)
-				exec.mu.Lock()
-				exec.objects[name] = item.Copy()
-				exec.mu.Unlock()
+		item, done, err := it.Next()
+		if err != nil {
+			iterErr = errs.WithDebug(err, node.Debug)
+			break
+		}
+		if done {
+			break
+		}
+		item = item.Copy()
 
-				_, e := exec.Execute(node.Children)
-				if e != nil && err == nil {
-					err = e
-				}
+		mu.Lock()
+		results = append(results, lang.NewNil("nil", node.Debug))
+		errList = append(errList, nil)
+		mu.Unlock()
+
+		child := thread.Fork()
+		threads = append(threads, child)
 
-				wg.Done()
-			}()
+		if slots != nil {
+			slots <- struct{}{}
 		}
 
-		wg.Wait()
-		return nil, err
-	case lang.TString:
-		var wg sync.WaitGroup
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if slots != nil {
+				defer func() { <-slots }()
+			}
 
-		str := strings.Split(iterable.Value().(string), "")
-		wg.Add(len(str))
+			if stopped.Load() {
+				return
+			}
 
-		var err error
+			var ret lang.Object
 
-		for _, item := range str {
-			go func() {
-				exec := NewExecuter(ExecuterScopeBlock, ex.runtime, ex)
-				exec.mu.Lock()
-				exec.objects[name] = lang.NewString(name, string(item), node.Debug)
-				exec.mu.Unlock()
+			exec := NewExecuter(ExecuterScopeBlock, ex.runtime, ex)
+			exec.ctx = race.NewContext(ex.context(), child)
 
-				_, e := exec.Execute(node.Children)
+			err := exec.bindLoopItem(node, name, item)
+			if err == nil {
+				ret, err = exec.Execute(node.Children)
+			}
 
-				if e != nil && err == nil {
-					err = e
+			if err != nil {
+				mu.Lock()
+				errList[i] = err
+				mu.Unlock()
+				return
+			}
+
+			if sig, ok := ret.(*loopSignal); ok {
+				if sig.isBreak() {
+					stopped.Store(true)
 				}
+				return
+			}
 
-				wg.Done()
-			}()
-		}
+			if ret != nil {
+				mu.Lock()
+				results[i] = ret
+				mu.Unlock()
+			}
+		}(i)
+	}
 
-		wg.Wait()
+	wg.Wait()
+
+	// everything the iterations did happens before the code after the loop
+	for _, child := range threads {
+		thread.Join(child)
+	}
+
+	if err := errs.Join(append(errList, iterErr)...); err != nil {
 		return nil, err
-	case lang.TInt:
-		var wg sync.WaitGroup
+	}
 
-		iterableValue := iterable.Value().(int)
-		wg.Add(iterableValue)
+	if expression {
+		return lang.NewList("spin", results, node.Debug), nil
+	}
+	return nil, nil
+}
 
-		var err error
+// spinLimit evaluates the limit option of a spin loop, 0 means no limit
+func (e *Executer) spinLimit(node *models.Node) (int, error) {
+	if !node.HasFlag("limit") {
+		return 0, nil
+	}
 
-		for item := 0; item < iterableValue; item++ {
-			go func() {
-				exec := NewExecuter(ExecuterScopeBlock, ex.runtime, ex)
-				exec.mu.Lock()
-				exec.objects[name] = lang.NewInteger(name, item, node.Debug)
-				exec.mu.Unlock()
+	limit, err := e.evaluateExpression(node.Args[2])
+	if err != nil {
+		return 0, errs.WithDebug(err, node.Debug)
+	}
 
-				_, e := exec.Execute(node.Children)
+	if limit == nil || limit.Type() != lang.TInt || limit.Value().(int) < 1 {
+		return 0, Error(ErrInvalidValue, node.Debug, "spin limit must be a positive integer")
+	}
 
-				if e != nil && err == nil {
-					err = e
-				}
+	return limit.Value().(int), nil
+}
+
+// handleMatch handles match tokens.
+// In expression mode the value of the matching arm is returned,
+// otherwise only the returns of block arms are propagated.
+func (e *Executer) handleMatch(node *models.Node, expression bool) (lang.Object, error) {
+	subject, err := e.evaluateExpression(&models.Node{
+		Type:         tokens.Match,
+		VariableType: tokens.ExpressionVariable,
+		Children:     node.Args,
+		Debug:        node.Debug,
+	})
+	if err != nil {
+		return nil, errs.WithDebug(err, node.Debug)
+	}
+
+	if subject == nil {
+		subject = lang.NewNil("nil", node.Debug)
+	}
+
+	zap.L().Debug("handling match", zap.Any("subject", subject))
+
+	if err := e.checkExhaustive(node, subject); err != nil {
+		return nil, err
+	}
+
+	for _, arm := range node.Children {
+		bindings := make(map[string]lang.Object)
+		if err := e.destructure(arm.Args[0], subject, bindings); err != nil {
+			if errors.Is(err, ErrPatternMismatch) {
+				continue
+			}
+			return nil, err
+		}
 
-				wg.Done()
-			}()
+		ex := NewExecuter(ExecuterScopeBlock, e.runtime, e).WithName(e.name + "#match")
+		for name, obj := range bindings {
+			ex.BindObject(name, obj)
 		}
 
-		wg.Wait()
+		if len(arm.Args) > 1 {
+			guard, err := ex.evaluateExpression(arm.Args[1])
+			if err != nil {
+				return nil, errs.WithDebug(err, arm.Args[1].Debug)
+			}
+
+			if guard == nil || guard.Type() != lang.TBool {
+				return nil, Error(ErrExpectedBoolean, arm.Args[1].Debug)
+			}
+
+			if !guard.Value().(bool) {
+				continue
+			}
+		}
+
+		var ret lang.Object
+		if arm.HasFlag("block") {
+			ret, err = ex.Execute(arm.Children)
+		} else {
+			ret, err = ex.evaluateExpression(arm)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !expression {
+			if arm.HasFlag("block") {
+				return ret, nil
+			}
+			return nil, nil
+		}
+
+		if ret == nil {
+			ret = lang.NewNil("nil", arm.Debug)
+		}
+		return ret, nil
+	}
+
+	if expression {
+		return lang.NewNil("nil", node.Debug), nil
+	}
+
+	return nil, nil
+}
+
+// handleComprehension evaluates a list comprehension like `[x * 2 for x in items if x > 0]`,
+// the items are evaluated like the body of a for loop and collected into a list
+func (e *Executer) handleComprehension(node *models.Node) (lang.Object, error) {
+	name, ex, iterable, err := e.initFor(node)
+	if err != nil {
 		return nil, err
 	}
+
+	it, ok := lang.IteratorOf(iterable)
+	if !ok {
+		return nil, Error(ErrExpectedIterable, node.Debug, gotErr(iterable.Type()))
+	}
+
+	if closer, ok := it.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	var items []lang.Object
+	for {
+		item, done, err := it.Next()
+		if err != nil {
+			return nil, errs.WithDebug(err, node.Debug)
+		}
+		if done {
+			break
+		}
+
+		exec := NewExecuter(ExecuterScopeBlock, ex.runtime, ex)
+		if err := exec.bindLoopItem(node, name, item.Copy()); err != nil {
+			return nil, err
+		}
+
+		if len(node.Children) > 1 {
+			condition, err := exec.evaluateExpression(node.Children[1])
+			if err != nil {
+				return nil, errs.WithDebug(err, node.Children[1].Debug)
+			}
+
+			if condition == nil || condition.Type() != lang.TBool {
+				return nil, Error(ErrExpectedBoolean, node.Children[1].Debug)
+			}
+
+			if !condition.Value().(bool) {
+				continue
+			}
+		}
+
+		value, err := exec.evaluateExpression(node.Children[0])
+		if err != nil {
+			return nil, errs.WithDebug(err, node.Children[0].Debug)
+		}
+		if value == nil {
+			value = lang.NewNil("nil", node.Debug)
+		}
+
+		items = append(items, value)
+	}
+
+	return lang.NewList("list", items, node.Debug), nil
+}
+
+// bindLoopItem binds the current item of a for or spin loop to the loop variable,
+// or destructures it into the names of the loop head pattern
+func (e *Executer) bindLoopItem(node *models.Node, name string, item lang.Object) error {
+	if !node.HasFlag("destructure") {
+		e.BindObject(name, item)
+		return nil
+	}
+
+	bindings := make(map[string]lang.Object)
+	if err := e.destructure(node.Args[0], item, bindings); err != nil {
+		return err
+	}
+
+	for name, obj := range bindings {
+		e.BindObject(name, obj)
+	}
+
+	return nil
 }
 
 func (e *Executer) initFor(node *models.Node) (string, *Executer, lang.Object, error) {
 	ex := NewExecuter(ExecuterScopeBlock, e.runtime, e).WithName(e.name)
 
-	if len(node.Args) != 2 {
+	if len(node.Args) != 2 && (len(node.Args) != 3 || !node.HasFlag("limit")) {
 		return "", nil, nil, Error(ErrInvalidValue, node.Debug, "expected 1 identifier and 1 iterable expression")
 	}
 
 	// stage 1: setting up the iterator and iterable
 
-	// make sure the variable is a let, not a reference
-	node.Args[0].Type = tokens.Let
-	node.Args[0].VariableType = tokens.NilVariable
-	node.Args[0].Reference = false
-	name, _, err := e.createObjectFromNode(node.Args[0])
-	if err != nil {
-		return "", nil, nil, err
+	var name string
+
+	if !node.HasFlag("destructure") {
+		// make sure the variable is a let, not a reference
+		node.Args[0].Type = tokens.Let
+		node.Args[0].VariableType = tokens.NilVariable
+		node.Args[0].Reference = false
+
+		var err error
+		name, _, err = e.createObjectFromNode(node.Args[0])
+		if err != nil {
+			return "", nil, nil, err
+		}
 	}
 
 	_, iterable, err := e.createObjectFromNode(&models.Node{
@@ -302,5 +517,18 @@ func (e *Executer) initFor(node *models.Node) (string, *Executer, lang.Object, e
 		return "", nil, nil, err
 	}
 
+	// definitions can be iterated by implementing `$iter`
+	if iterable.Type() == lang.TInstance {
+		if method := iterable.Method("$iter"); method != nil {
+			iterable, err = method.Execute(nil)
+			if err != nil {
+				return "", nil, nil, errs.WithDebug(err, node.Debug)
+			}
+			if iterable == nil {
+				return "", nil, nil, Error(ErrExpectedIterable, node.Debug, "$iter returned nothing")
+			}
+		}
+	}
+
 	return name, ex, iterable, nil
 }
@@ -0,0 +1,98 @@
+package runtimev2
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/flarelang/flare/internal/ast"
+	"github.com/flarelang/flare/internal/lexer"
+	"github.com/flarelang/flare/internal/state"
+)
+
+// run executes the source and returns the executer of the file
+func run(t *testing.T, s string) *Executer {
+	ex, err := execute(t, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ex
+}
+
+// execute executes the source and returns the executer of the file and the error of the execution
+func execute(t *testing.T, s string) (*Executer, error) {
+	ts, err := lexer.New("<test>").Parse(strings.NewReader(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nodes, err := ast.NewBuilder().Build(ts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := New(state.Default())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = r.Execute(nodes)
+	return r.executers[""], err
+}
+
+// value returns the value of a variable of the executer
+func value(t *testing.T, e *Executer, name string) any {
+	obj, err := e.GetVariable(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return obj.Value()
+}
+
+// writeFiles writes the files into a new directory and returns the directory
+func writeFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// runFile executes the file with the runtime like the interpreter does
+func runFile(t *testing.T, r *Runtime, path string) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts, err := lexer.New(path).Parse(strings.NewReader(string(b)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nodes, err := ast.NewBuilder().Build(ts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = r.ExecuteFile(path, nodes)
+	return err
+}
+
+// newRuntime creates a runtime without search paths from the environment
+func newRuntime(t *testing.T) *Runtime {
+	t.Setenv(SearchPathVariable, "")
+
+	r, err := New(state.Default())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
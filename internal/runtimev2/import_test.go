@@ -0,0 +1,141 @@
+package runtimev2
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/flarelang/flare/internal/state"
+	"github.com/flarelang/flare/pkg/pkgman"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ImportRelative(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"main.fl":  `let value = import("lib/a.fl");`,
+		"lib/a.fl": `return import("b.fl") + 1;`,
+		"lib/b.fl": `return 2;`,
+	})
+
+	r := newRuntime(t)
+	assert.NoError(t, runFile(t, r, filepath.Join(dir, "main.fl")))
+	assert.Equal(t, 3, value(t, r.executers[""], "value"), "imports must be resolved relative to the importing file")
+}
+
+func Test_ImportOnce(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"main.fl": `
+			let loads = 0;
+			let first = import("counter.fl");
+			let second = import("counter.fl");
+
+			spin i in [1, 2, 3, 4] {
+				import("slow.fl");
+			}
+		`,
+		"counter.fl": `
+			loads++;
+			return loads;
+		`,
+		"slow.fl": `
+			use thread;
+			thread.sleep(50);
+			loads++;
+		`,
+	})
+
+	r := newRuntime(t)
+	assert.NoError(t, runFile(t, r, filepath.Join(dir, "main.fl")), "concurrent imports of a file are not a cycle")
+
+	ex := r.executers[""]
+	assert.Equal(t, 1, value(t, ex, "first"))
+	assert.Equal(t, 1, value(t, ex, "second"), "a second import must return the result of the first one")
+	assert.Equal(t, 2, value(t, ex, "loads"), "a file imported by threads at the same time must run once")
+}
+
+func Test_ImportCycle(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"a.fl":    `import("b.fl");`,
+		"b.fl":    `import("a.fl");`,
+		"self.fl": `import("self.fl");`,
+	})
+
+	a, b := filepath.Join(dir, "a.fl"), filepath.Join(dir, "b.fl")
+	err := runFile(t, newRuntime(t), a)
+	assert.ErrorContains(t, err, "import cycle")
+	assert.ErrorContains(t, err, strings.Join([]string{a, b, a}, " -> "), "the error must report the whole chain")
+
+	self := filepath.Join(dir, "self.fl")
+	assert.ErrorContains(t, runFile(t, newRuntime(t), self), self+" -> "+self)
+}
+
+func Test_ImportCycleThreads(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"main.fl": `
+			use thread;
+
+			let x = thread.spawn(fn() => import("x.fl"));
+			let y = thread.spawn(fn() => import("y.fl"));
+
+			error xErr: x.await();
+			error yErr: y.await();
+		`,
+		"x.fl": `
+			use thread;
+			thread.sleep(50);
+			import("y.fl");
+		`,
+		"y.fl": `
+			use thread;
+			thread.sleep(50);
+			import("x.fl");
+		`,
+	})
+
+	done := make(chan error, 1)
+	r := newRuntime(t)
+	go func() {
+		done <- runFile(t, r, filepath.Join(dir, "main.fl"))
+	}()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("imports waiting for each other in two threads must not deadlock")
+	}
+
+	ex := r.executers[""]
+	assert.Contains(t, value(t, ex, "xErr"), "import cycle")
+	assert.Contains(t, value(t, ex, "yErr"), "import cycle")
+}
+
+func Test_ImportSearchPaths(t *testing.T) {
+	shared := writeFiles(t, map[string]string{
+		"greet.fl": `return "hello";`,
+	})
+	dir := writeFiles(t, map[string]string{
+		"main.fl":    `let greeting = import("greet.fl");`,
+		"flare.yaml": "paths:\n  - " + shared + "\n",
+	})
+	main := filepath.Join(dir, "main.fl")
+
+	t.Setenv(SearchPathVariable, shared)
+	r, err := New(state.Default())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NoError(t, runFile(t, r, main))
+	assert.Equal(t, "hello", value(t, r.executers[""], "greeting"), "imports must be looked up in "+SearchPathVariable)
+
+	pm, err := pkgman.New(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r = newRuntime(t).WithSearchPaths(pm.SearchPaths()...)
+	assert.NoError(t, runFile(t, r, main))
+	assert.Equal(t, "hello", value(t, r.executers[""], "greeting"), "imports must be looked up in the paths of flare.yaml")
+
+	assert.Error(t, runFile(t, newRuntime(t), main), "a file outside of the search paths must not be found")
+}
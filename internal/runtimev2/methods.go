@@ -1,8 +1,14 @@
 package runtimev2
 
 import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
 	"github.com/flarelang/flare/internal/errs"
 	"github.com/flarelang/flare/internal/models"
+	"github.com/flarelang/flare/internal/tokens"
 	"github.com/flarelang/flare/lang"
 	"go.uber.org/zap"
 )
@@ -11,86 +17,122 @@ import (
 func (e *Executer) callFunctionFromNode(n *models.Node) (lang.Object, error) {
 	name := n.Content
 
-	method, err := e.GetMethod(name)
+	getMethod := e.GetMethod
+	if strings.Contains(name, "?.") {
+		getMethod = e.getOptionalMethod
+	}
+
+	method, err := getMethod(name)
+	if err != nil {
+		return nil, errs.WithDebug(err, n.Debug)
+	}
+
+	// the object of a nil-safe call like `user?.greet()` is nil
+	if method == nil {
+		return lang.NewNil(name, n.Debug), nil
+	}
+
+	zap.L().Debug("calling function", zap.String("name", name), zap.Int("expectedArgs", len(method.Args())), zap.Int("givenArgs", len(n.Args)))
+
+	args, err := e.getFunctionArguments(name, method, n.Args, n.Debug)
+	if err != nil {
+		return nil, errs.WithDebug(err, n.Debug)
+	}
+
+	r, err := lang.ExecuteContext(e.context(), method, args)
+
 	if err != nil {
 		return nil, errs.WithDebug(err, n.Debug)
 	}
 
+	if len(n.Children) > 0 {
+		return e.getObjectValueByNodes(r, n.Children)
+	}
+
+	return r, nil
+}
+
+// getFunctionArguments evaluates the positional and named argument nodes of a call
+// and orders them by the arguments of the method, filling in default values
+// and collecting the remaining positional arguments into the variadic argument.
+func (e *Executer) getFunctionArguments(name string, method lang.Method, nodeArgs []*models.Node, debug *models.Debug) ([]lang.Object, error) {
 	var (
+		argNames        = method.Args()
+		args            = make([]lang.Object, len(argNames))
 		variadicArgName string
+		variadicArgs    = make([]lang.Object, 0)
 		isVariadic      bool
+		positional      int
+		seenNamed       bool
 	)
 
-	variadicMethod, ok := method.(lang.VariadicMethod)
-	if ok {
+	if variadicMethod, ok := method.(lang.VariadicMethod); ok {
 		isVariadic = variadicMethod.HasVariadicArg()
 		variadicArgName = variadicMethod.GetVariadicArg()
 	}
 
-	expectedArgs := len(method.Args())
-	givenArgs := len(n.Args)
-
-	zap.L().Debug("calling function", zap.String("name", name), zap.Int("expectedArgs", expectedArgs), zap.Int("givenArgs", givenArgs), zap.Bool("isVariadic", isVariadic))
+	for _, child := range nodeArgs {
+		if child.HasFlag("named") {
+			seenNamed = true
 
-	if !isVariadic {
-		// argument number should match
-		if expectedArgs != givenArgs {
-			return nil, Error(ErrInvalidArguments, n.Debug, joinCustom(fnErr(name), expectedErr(expectedArgs, givenArgs)))
-		}
-	} else {
-		// if the arguments are less than expected return error
-		if expectedArgs > givenArgs {
-			return nil, Error(ErrInvalidArguments, n.Debug, joinCustom(fnErr(name), expectedErr(expectedArgs, givenArgs)))
-		}
-	}
-
-	args, err := e.getFunctionArguments(n.Args, method.Args())
-	if err != nil {
-		return nil, err
-	}
+			i := slices.Index(argNames, child.Content)
+			if i == -1 {
+				return nil, Error(ErrInvalidArguments, debug, joinCustom(fnErr(name), fmt.Sprintf("unknown argument '%s'", child.Content)))
+			}
 
-	if givenArgs > expectedArgs && isVariadic {
-		var variadicArgList = make([]lang.Object, givenArgs-expectedArgs)
+			if args[i] != nil {
+				return nil, Error(ErrInvalidArguments, debug, joinCustom(fnErr(name), fmt.Sprintf("argument '%s' given more than once", child.Content)))
+			}
 
-		for i := expectedArgs; i < givenArgs; i++ {
-			obj, err := e.getFunctionArgFromNode(n.Args[i])
+			obj, err := e.getFunctionArgFromNode(child)
 			if err != nil {
 				return nil, err
 			}
 
-			variadicArgList[i-expectedArgs] = obj
+			args[i] = obj
+			continue
 		}
 
-		variadicArg := lang.NewList(variadicArgName, variadicArgList, n.Debug)
-		args = append(args, variadicArg)
-	} else if isVariadic {
-		args = append(args, lang.NewList(variadicArgName, make([]lang.Object, 0), n.Debug))
-	}
+		if seenNamed {
+			return nil, Error(ErrInvalidArguments, debug, joinCustom(fnErr(name), "positional argument after named argument"))
+		}
 
-	r, err := method.Execute(args)
+		obj, err := e.getFunctionArgFromNode(child)
+		if err != nil {
+			return nil, err
+		}
 
-	if err != nil {
-		return nil, errs.WithDebug(err, n.Debug)
-	}
+		if positional < len(argNames) {
+			args[positional] = obj
+		} else if isVariadic {
+			variadicArgs = append(variadicArgs, obj)
+		} else {
+			return nil, Error(ErrInvalidArguments, debug, joinCustom(fnErr(name), expectedErr(len(argNames), len(nodeArgs))))
+		}
 
-	if len(n.Children) > 0 {
-		return e.getObjectValueByNodes(r, n.Children)
+		positional++
 	}
 
-	return r, nil
-}
-
-func (e *Executer) getFunctionArguments(nodeArgs []*models.Node, argNames []string) ([]lang.Object, error) {
-	args := make([]lang.Object, 0, len(argNames))
+	defaults, _ := method.(lang.DefaultArgsMethod)
 
 	for i, argName := range argNames {
-		obj, err := e.getFunctionArgFromNode(nodeArgs[i])
-		if err != nil {
-			return nil, err
+		if args[i] == nil {
+			if defaults == nil || !defaults.HasDefault(argName) {
+				return nil, Error(ErrInvalidArguments, debug, joinCustom(fnErr(name), fmt.Sprintf("missing argument '%s'", argName)))
+			}
+
+			obj, err := defaults.Default(argName)
+			if err != nil {
+				return nil, errs.WithDebug(err, debug)
+			}
+			args[i] = obj
 		}
 
-		obj.Rename(argName)
-		args = append(args, obj)
+		args[i].Rename(argName)
+	}
+
+	if isVariadic {
+		args = append(args, lang.NewList(variadicArgName, variadicArgs, debug))
 	}
 
 	zap.L().Debug("function arguments", zap.Any("args", args))
@@ -98,8 +140,29 @@ func (e *Executer) getFunctionArguments(nodeArgs []*models.Node, argNames []stri
 	return args, nil
 }
 
+// callMagicMethod calls a magic method like `$add` with already evaluated arguments
+func callMagicMethod(name string, method lang.Method, debug *models.Debug, args ...lang.Object) (lang.Object, error) {
+	argNames := method.Args()
+	if len(argNames) != len(args) {
+		return nil, Error(ErrInvalidArguments, debug, joinCustom(fnErr(name), expectedErr(len(argNames), len(args))))
+	}
+
+	for i, arg := range args {
+		args[i] = arg.Copy()
+		args[i].Rename(argNames[i])
+	}
+
+	value, err := method.Execute(args)
+	if err != nil {
+		return nil, errs.WithDebug(err, debug)
+	}
+	return value, nil
+}
+
 func (e *Executer) getFunctionArgFromNode(child *models.Node) (lang.Object, error) {
 	if child.Reference {
+		e.raceRead(child.Content, child.Debug)
+
 		obj, err := e.GetVariable(child.Content)
 		if err != nil {
 			return nil, errs.WithDebug(err, child.Debug)
@@ -121,27 +184,94 @@ func (e *Executer) getFunctionArgFromNode(child *models.Node) (lang.Object, erro
 func (e *Executer) createMethodFromNode(n *models.Node) (string, lang.Method, error) {
 	name := n.Content
 
-	argsLen := len(n.Args)
-	var args []string
+	var (
+		args     []string
+		variadic string
+		// typed are the parameters with a type annotation
+		typed []*models.Node
+	)
 
-	if argsLen > 0 {
-		args = make([]string, argsLen)
-	}
+	for _, arg := range n.Args {
+		if arg.HasFlag("variadic") {
+			variadic = arg.Content
+			continue
+		}
+		args = append(args, arg.Content)
 
-	for i, arg := range n.Args {
-		args[i] = arg.Content
+		if typeAnnotation(arg, "type") != "" {
+			typed = append(typed, arg)
+		}
 	}
 
-	method := lang.NewFunction(func(args []lang.Object) (lang.Object, error) {
+	// the function closes over e, the scope it is created in. Outer variables are looked up
+	// and assigned in that scope when the function runs, so they are shared with it and with
+	// every other function created there, while the arguments are copies.
+	method := lang.NewContextFunction(func(ctx context.Context, args []lang.Object) (lang.Object, error) {
 		ex := NewExecuter(ExecuterScopeFunction, e.runtime, e).WithName(e.name + ".{" + name + "}")
+		// functions called without a context, like callbacks of builtins, keep the context of their scope
+		if ctx != nil {
+			ex.ctx = ctx
+		}
 
 		for _, arg := range args {
 			ex.BindObject(arg.Name(), arg)
 		}
 
+		for _, param := range typed {
+			ex.declareType(param.Content, typeAnnotation(param, "type"))
+
+			arg, err := ex.GetVariable(param.Content)
+			if err != nil {
+				return nil, err
+			}
+			// the error gets the position of the call
+			if err := e.checkType(typeAnnotation(param, "type"), arg, "argument "+param.Content); err != nil {
+				return nil, err
+			}
+		}
+
+		// generator functions run lazily, their body is executed while iterating
+		if n.HasFlag("generator") {
+			return newGenerator(name, ex, n.Children, n.Debug), nil
+		}
+
 		r, err := ex.Execute(n.Children)
-		return r, err
-	}).WithArgs(args).WithDebug(n.Debug)
+		if err = ex.runDefers(err); err != nil {
+			return nil, err
+		}
+
+		if err := escapedLoopSignal(r); err != nil {
+			return nil, err
+		}
+
+		if returns := typeAnnotation(n, "returns"); returns != "" {
+			if err := e.checkType(returns, r, "return value of "+fnErr(name)); err != nil {
+				return nil, errs.WithDebug(err, n.Debug)
+			}
+		}
+
+		return r, nil
+	}).WithArgs(args).WithVariadicArg(variadic).WithDebug(n.Debug).WithScopeContext()
+
+	for _, arg := range n.Args {
+		if !arg.HasFlag("default") {
+			continue
+		}
+
+		value := &models.Node{
+			Type:         tokens.FuncArg,
+			VariableType: tokens.ExpressionVariable,
+			Content:      arg.Content,
+			Children:     arg.Children,
+			Debug:        arg.Debug,
+		}
+
+		// default values are evaluated in the scope of the declaration on every call
+		method.WithDefault(arg.Content, func() (lang.Object, error) {
+			_, obj, err := e.createObjectFromNode(value)
+			return obj, err
+		})
+	}
 
 	zap.L().Debug("creating method from node", zap.String("name", name), zap.Any("args", args))
 
@@ -18,7 +18,7 @@ func (e *Executer) accessObject(obj lang.Object, accessors []*models.Node) (lang
 
 	currentAccessor := accessors[0]
 
-	if obj.Type() != lang.TList && obj.Type() != lang.TDefinition && obj.Type() != lang.TArray {
+	if obj.Type() != lang.TList && obj.Type() != lang.TDefinition && obj.Type() != lang.TArray && obj.Type() != lang.TInstance {
 		return nil, Error(ErrInvalidIndexAccess, accessors[0].Debug, obj.Type())
 	}
 
@@ -52,6 +52,12 @@ func (e *Executer) accessObject(obj lang.Object, accessors []*models.Node) (lang
 			return nil, Error(ErrKeyNotFound, currentAccessor.Debug, access)
 		}
 		value = o
+	} else if obj.Type() == lang.TInstance {
+		o, err := e.indexInstance(obj, access, currentAccessor)
+		if err != nil {
+			return nil, err
+		}
+		value = o
 	} else {
 		return nil, Error(ErrInvalidValue, currentAccessor.Debug, fmt.Sprintf("unsupported object type: %s", obj.Type()))
 	}
@@ -81,8 +87,33 @@ func (e *Executer) accessObject(obj lang.Object, accessors []*models.Node) (lang
 	return ob, nil
 }
 
+// indexInstance indexes a definition instance through its `$index` method
+func (e *Executer) indexInstance(obj lang.Object, access any, accessor *models.Node) (lang.Object, error) {
+	method := obj.Method("$index")
+	if method == nil {
+		return nil, Error(ErrInvalidIndexAccess, accessor.Debug, lang.TypeOf(obj))
+	}
+
+	key, err := lang.FromValue(access)
+	if err != nil {
+		return nil, Error(ErrInvalidIndexAccess, accessor.Debug, err)
+	}
+
+	value, err := callMagicMethod("$index", method, accessor.Debug, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if value == nil {
+		return lang.NewNil(obj.Name(), accessor.Debug), nil
+	}
+	return value, nil
+}
+
 func (e *Executer) getObjAccessors(accessor *models.Node) (any, error) {
 	if accessor.VariableType == tokens.ReferenceVariable {
+		e.raceRead(accessor.Content, accessor.Debug)
+
 		obj, err := e.GetVariable(accessor.Content)
 		if err != nil {
 			return nil, errs.WithDebug(err, accessor.Debug)
@@ -97,3 +128,96 @@ func (e *Executer) getObjAccessors(accessor *models.Node) (any, error) {
 
 	return accessor.Value, nil
 }
+
+// assignIndexFromNode assigns a value to an indexed element, e.g. `list[0] = value`
+func (e *Executer) assignIndexFromNode(n *models.Node) error {
+	_, value, err := e.createObjectFromNode(n)
+	if err != nil {
+		return errs.WithDebug(err, n.Debug)
+	}
+
+	return e.assignIndex(n, value)
+}
+
+// assignIndex assigns the value to the element the index accessors of the node point to
+func (e *Executer) assignIndex(n *models.Node, value lang.Object) error {
+	// changing an element changes the variable holding the list or array
+	e.raceWrite(n.Content, n.Debug)
+
+	obj, err := e.GetVariable(n.Content)
+	if err != nil {
+		return errs.WithDebug(err, n.Debug)
+	}
+
+	if !obj.IsMutable() {
+		return Error(ErrConstantReassignment, n.Debug, n.Content)
+	}
+
+	// walk to the container of the last accessor without copying
+	for _, accessor := range n.Args[:len(n.Args)-1] {
+		access, err := e.getObjAccessors(accessor)
+		if err != nil {
+			return err
+		}
+
+		switch obj.Type() {
+		case lang.TList:
+			li := obj.Value().([]lang.Object)
+			i, ok := access.(int)
+			if !ok || i < 0 || i >= len(li) {
+				return Error(ErrIndexOutOfBounds, accessor.Debug, fmt.Sprintf("%d length: %d", i, len(li)))
+			}
+			obj = li[i]
+		case lang.TArray:
+			o, ok := obj.(*lang.Array).Access(access)
+			if !ok {
+				return Error(ErrKeyNotFound, accessor.Debug, access)
+			}
+			obj = o
+		case lang.TInstance:
+			obj, err = e.indexInstance(obj, access, accessor)
+			if err != nil {
+				return err
+			}
+		default:
+			return Error(ErrInvalidIndexAccess, accessor.Debug, obj.Type())
+		}
+	}
+
+	accessor := n.Args[len(n.Args)-1]
+	access, err := e.getObjAccessors(accessor)
+	if err != nil {
+		return err
+	}
+
+	switch obj.Type() {
+	case lang.TList:
+		li := obj.Value().([]lang.Object)
+		i, ok := access.(int)
+		if !ok || i < 0 || i >= len(li) {
+			return Error(ErrIndexOutOfBounds, accessor.Debug, fmt.Sprintf("%d length: %d", i, len(li)))
+		}
+		li[i] = value.Copy()
+		return nil
+	case lang.TArray, lang.TInstance:
+		name := "$bind"
+		if obj.Type() == lang.TInstance {
+			name = "$setIndex"
+		}
+
+		method := obj.Method(name)
+		if method == nil {
+			return Error(ErrInvalidIndexAccess, accessor.Debug, lang.TypeOf(obj))
+		}
+
+		key, err := lang.FromValue(access)
+		if err != nil {
+			return Error(ErrInvalidIndexAccess, accessor.Debug, err)
+		}
+
+		_, err = callMagicMethod(name, method, accessor.Debug, key, value)
+		return err
+	default:
+		return Error(ErrInvalidIndexAccess, accessor.Debug, obj.Type())
+	}
+}
@@ -68,9 +68,10 @@ func (e *Executer) createObjectFromNode(n *models.Node) (string, lang.Object, er
 		}
 		obj = li
 	case tokens.InlineValue:
-		typ := e.getVariableTypeFromType(n)
-		n.VariableType = typ
-		return e.createObjectFromNode(n)
+		// the node is copied, the same node can be evaluated by multiple threads
+		inline := *n
+		inline.VariableType = e.getVariableTypeFromType(n)
+		return e.createObjectFromNode(&inline)
 	case tokens.ExpressionVariable:
 		expr, err := e.evaluateExpression(n)
 		if err != nil {
@@ -84,6 +85,8 @@ func (e *Executer) createObjectFromNode(n *models.Node) (string, lang.Object, er
 			refName = refVal
 		}
 
+		e.raceRead(refName, n.Debug)
+
 		ref, err := e.GetVariable(refName)
 		if err != nil {
 			return "", nil, Error(err, n.Debug)
@@ -114,6 +117,24 @@ func (e *Executer) createObjectFromNode(n *models.Node) (string, lang.Object, er
 		}
 
 		obj = lang.NewFn("<inlineFn>", n.Debug, fn)
+	case tokens.MatchVariable:
+		var err error
+		obj, err = e.handleMatch(n, true)
+		if err != nil {
+			return "", nil, errs.WithDebug(err, n.Debug)
+		}
+	case tokens.SpinVariable:
+		var err error
+		obj, err = e.handleSpin(n, true)
+		if err != nil {
+			return "", nil, errs.WithDebug(err, n.Debug)
+		}
+	case tokens.ComprehensionVariable:
+		var err error
+		obj, err = e.handleComprehension(n)
+		if err != nil {
+			return "", nil, errs.WithDebug(err, n.Debug)
+		}
 	case tokens.FunctionCallVariable:
 		var err error
 		obj, err = e.callFunctionFromNode(n)
@@ -156,9 +177,77 @@ func (e *Executer) assignObjectFromNode(n *models.Node) error {
 
 	zap.L().Debug("assigning object from node", zap.String("name", name), zap.Any("object", obj))
 
+	e.raceWrite(name, n.Debug)
+
 	return e.AssignVariable(name, obj)
 }
 
+// compoundAssignFromNode assigns the result of a compound assignment like `count += 1`,
+// `??=` only assigns and evaluates the value if the variable is nil
+func (e *Executer) compoundAssignFromNode(n *models.Node, operator string) error {
+	name := n.Content
+
+	e.raceRead(name, n.Debug)
+
+	current, err := e.GetVariable(name)
+	if err != nil {
+		return errs.WithDebug(err, n.Debug)
+	}
+
+	if n.HasFlag("index") {
+		current, err = e.accessObject(current, n.Args)
+		if err != nil {
+			return errs.WithDebug(err, n.Debug)
+		}
+	}
+
+	if operator == "??=" && current.Type() != lang.TNil {
+		return nil
+	}
+
+	_, value, err := e.createObjectFromNode(n)
+	if err != nil {
+		return errs.WithDebug(err, n.Debug)
+	}
+
+	if operator != "??=" {
+		ex := NewExecuter(ExecuterScopeBlock, e.runtime, e).WithName(e.name + "#assign")
+		ex.BindObject("$left", current)
+		ex.BindObject("$right", value)
+
+		op := compoundOperators[operator]
+		value, err = ex.evaluateExpression(&models.Node{
+			VariableType: tokens.ExpressionVariable,
+			Content:      name,
+			Children: []*models.Node{
+				{Type: tokens.Identifier, VariableType: tokens.ReferenceVariable, Reference: true, Content: "$left", Debug: n.Debug},
+				{Type: op, Content: op.String(), Debug: n.Debug},
+				{Type: tokens.Identifier, VariableType: tokens.ReferenceVariable, Reference: true, Content: "$right", Debug: n.Debug},
+			},
+			Debug: n.Debug,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if n.HasFlag("index") {
+		return e.assignIndex(n, value)
+	}
+
+	e.raceWrite(name, n.Debug)
+
+	return e.AssignVariable(name, value)
+}
+
+// compoundOperators maps the compound assignments to the operators they apply
+var compoundOperators = map[string]tokens.TokenType{
+	"+=": tokens.Addition,
+	"-=": tokens.Subtraction,
+	"*=": tokens.Multiplication,
+	"/=": tokens.Division,
+}
+
 // createListFromNode creates a list from a node
 func (e *Executer) createListFromNode(n *models.Node) (lang.Object, error) {
 	name := n.Content
@@ -192,7 +281,94 @@ func (e *Executer) createObjectFromDefinitionNode(n *models.Node) (string, lang.
 
 	zap.L().Debug("creating object from definition node", zap.String("name", name))
 
-	return name, lang.NewDefinition(e.name+"."+name, name, n.Debug, n.Children, ex), nil
+	def := lang.NewDefinition(e.name+"."+name, name, n.Debug, n.Children, ex)
+	ex.definition = def
+
+	if parentName, ok := n.Map["extends"].(string); ok {
+		obj, err := e.GetVariable(parentName)
+		if err != nil {
+			return "", nil, errs.WithDebug(err, n.Debug)
+		}
+
+		parent, ok := obj.(*lang.Definition)
+		if !ok {
+			return "", nil, Error(ErrInvalidParent, n.Debug, expectedErr(lang.TDefinition, obj.Type()))
+		}
+
+		def.WithParent(parent)
+	}
+
+	if names, ok := n.Map["implements"].([]string); ok {
+		interfaces := make([]*lang.Interface, len(names))
+
+		for i, ifaceName := range names {
+			obj, err := e.GetVariable(ifaceName)
+			if err != nil {
+				return "", nil, errs.WithDebug(err, n.Debug)
+			}
+
+			iface, ok := obj.(*lang.Interface)
+			if !ok {
+				return "", nil, Error(ErrInvalidInterface, n.Debug, expectedErr(lang.TInterface, obj.Type()))
+			}
+			interfaces[i] = iface
+		}
+
+		def.WithInterfaces(interfaces...)
+	}
+
+	return name, def, nil
+}
+
+// createInterfaceFromNode creates an interface from a node
+func (e *Executer) createInterfaceFromNode(n *models.Node) (string, lang.Object, error) {
+	var (
+		methods    []lang.InterfaceMethod
+		properties []string
+	)
+
+	for _, member := range n.Children {
+		if member.Type == tokens.Let {
+			properties = append(properties, member.Content)
+			continue
+		}
+
+		args := make([]string, 0, len(member.Args))
+		for _, arg := range member.Args {
+			if !arg.HasFlag("variadic") {
+				args = append(args, arg.Content)
+			}
+		}
+
+		methods = append(methods, lang.InterfaceMethod{Name: member.Content, Args: args})
+	}
+
+	zap.L().Debug("creating interface from node", zap.String("name", n.Content))
+
+	return n.Content, lang.NewInterface(e.name+"."+n.Content, n.Content, n.Debug, methods, properties), nil
+}
+
+// createEnumFromNode creates an enum from a node, the associated values are evaluated once
+func (e *Executer) createEnumFromNode(n *models.Node) (string, lang.Object, error) {
+	enum := lang.NewEnum(e.name+"."+n.Content, n.Content, n.Debug)
+
+	zap.L().Debug("creating enum from node", zap.String("name", n.Content))
+
+	for _, member := range n.Args {
+		var value lang.Object
+
+		if member.VariableType != tokens.NilVariable {
+			_, obj, err := e.createObjectFromNode(member)
+			if err != nil {
+				return "", nil, err
+			}
+			value = lang.Immute(obj)
+		}
+
+		enum.Add(member.Content, value, member.Debug)
+	}
+
+	return n.Content, enum, nil
 }
 
 func (e *Executer) getObjectValueByNodes(obj lang.Object, nodes []*models.Node) (lang.Object, error) {
@@ -209,20 +385,12 @@ func (e *Executer) getObjectValueByNodes(obj lang.Object, nodes []*models.Node)
 				return nil, Error(ErrInvalidObjectAccess, node.Debug, fnErr(node.Content))
 			}
 
-			if len(node.Args) != len(m.Args()) {
-				return nil, Error(ErrInvalidArguments, node.Debug, expectedErr(len(m.Args()), node.Args))
-			}
-
-			args := make([]lang.Object, len(node.Args))
-			for i, arg := range node.Args {
-				_, o, err := e.createObjectFromNode(arg)
-				if err != nil {
-					return nil, errs.WithDebug(err, arg.Debug)
-				}
-				args[i] = o
+			args, err := e.getFunctionArguments(node.Content, m, node.Args, node.Debug)
+			if err != nil {
+				return nil, errs.WithDebug(err, node.Debug)
 			}
 
-			r, err := m.Execute(args)
+			r, err := lang.ExecuteContext(e.context(), m, args)
 			if err != nil {
 				return nil, errs.WithDebug(err, node.Debug)
 			}
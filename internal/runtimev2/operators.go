@@ -0,0 +1,270 @@
+package runtimev2
+
+import (
+	"fmt"
+
+	"github.com/Knetic/govaluate"
+	"github.com/flarelang/flare/internal/errs"
+	"github.com/flarelang/flare/internal/models"
+	"github.com/flarelang/flare/lang"
+)
+
+// operatorMethods maps the operators to the magic methods definitions can use to overload them
+var operatorMethods = map[string]string{
+	"+":  "$add",
+	"-":  "$sub",
+	"*":  "$mul",
+	"/":  "$div",
+	"==": "$eq",
+	"<":  "$lt",
+}
+
+// operatorPrecedence follows the precedence of the expression evaluator
+var operatorPrecedence = map[string]int{
+	"||": 1,
+	"&&": 2,
+	"==": 3, "!=": 3,
+	"<": 4, "<=": 4, ">": 4, ">=": 4,
+	"+": 5, "-": 5,
+	"*": 6, "/": 6, "%": 6,
+	"**": 7,
+}
+
+// hasInstanceOperand reports whether one of the operands is a definition instance or an enum value
+func hasInstanceOperand(args map[string]any) bool {
+	for _, arg := range args {
+		if obj, ok := arg.(lang.Object); ok && (obj.Type() == lang.TInstance || obj.Type() == lang.TEnumValue) {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluateOverloaded evaluates an expression that has definition instances as operands.
+// Operators on instances are dispatched to their magic methods,
+// every other operation is evaluated like a regular expression.
+func (e *Executer) evaluateOverloaded(expressionList []string, args map[string]any, n *models.Node) (lang.Object, error) {
+	var (
+		output    []lang.Object
+		operators []string
+		unary     []bool
+	)
+
+	operand := func(name string) (lang.Object, error) {
+		obj, err := lang.FromValue(args[name])
+		if err != nil {
+			return nil, Error(ErrInvalidValue, n.Debug, err)
+		}
+		return obj, nil
+	}
+
+	apply := func() error {
+		op, isUnary := operators[len(operators)-1], unary[len(unary)-1]
+		operators, unary = operators[:len(operators)-1], unary[:len(unary)-1]
+
+		if isUnary {
+			if len(output) < 1 {
+				return Error(ErrExpectedExpression, n.Debug)
+			}
+
+			value, err := e.applyUnaryOperator(op, output[len(output)-1], n)
+			if err != nil {
+				return err
+			}
+			output[len(output)-1] = value
+			return nil
+		}
+
+		if len(output) < 2 {
+			return Error(ErrExpectedExpression, n.Debug)
+		}
+
+		left, right := output[len(output)-2], output[len(output)-1]
+		value, err := e.applyOperator(op, left, right, n)
+		if err != nil {
+			return err
+		}
+
+		output = append(output[:len(output)-2], value)
+		return nil
+	}
+
+	expectOperand := true
+	for _, item := range expressionList {
+		if _, ok := args[item]; ok {
+			obj, err := operand(item)
+			if err != nil {
+				return nil, err
+			}
+
+			output = append(output, obj)
+			expectOperand = false
+			continue
+		}
+
+		// operators in operand position are unary, like `-x` or `!ok`
+		if expectOperand {
+			operators = append(operators, item)
+			unary = append(unary, true)
+			continue
+		}
+
+		for len(operators) > 0 && (unary[len(unary)-1] || operatorPrecedence[operators[len(operators)-1]] >= operatorPrecedence[item]) {
+			if err := apply(); err != nil {
+				return nil, err
+			}
+		}
+
+		operators = append(operators, item)
+		unary = append(unary, false)
+		expectOperand = true
+	}
+
+	for len(operators) > 0 {
+		if err := apply(); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(output) != 1 {
+		return nil, Error(ErrExpectedExpression, n.Debug)
+	}
+
+	result := output[0]
+	result.Rename(n.Content)
+	return result, nil
+}
+
+// applyOperator applies a binary operator, using the magic methods of instances
+func (e *Executer) applyOperator(op string, left, right lang.Object, n *models.Node) (lang.Object, error) {
+	// enum values are only equal to the same value of the same enum
+	if left.Type() == lang.TEnumValue || right.Type() == lang.TEnumValue {
+		if op != "==" && op != "!=" {
+			return nil, Error(ErrUnsupportedOperator, n.Debug, fmt.Sprintf("%s %s %s", lang.TypeOf(left), op, lang.TypeOf(right)))
+		}
+		return lang.NewBool(n.Content, left.Value() == right.Value() == (op == "=="), n.Debug), nil
+	}
+
+	if left.Type() != lang.TInstance && right.Type() != lang.TInstance {
+		return e.evaluateValues(fmt.Sprintf("left %s right", op), map[string]any{
+			"left":  operandValue(left),
+			"right": operandValue(right),
+		}, n)
+	}
+
+	switch op {
+	case "==", "!=":
+		equal, err := e.instancesEqual(left, right, n)
+		if err != nil {
+			return nil, err
+		}
+		return lang.NewBool(n.Content, equal == (op == "=="), n.Debug), nil
+	case ">", "<=", ">=":
+		less, err := e.callOperator("<", left, right, n)
+		if err != nil {
+			return nil, err
+		}
+
+		if op == ">=" {
+			return lang.NewBool(n.Content, !less, n.Debug), nil
+		}
+
+		equal, err := e.instancesEqual(left, right, n)
+		if err != nil {
+			return nil, err
+		}
+
+		if op == "<=" {
+			return lang.NewBool(n.Content, less || equal, n.Debug), nil
+		}
+		return lang.NewBool(n.Content, !less && !equal, n.Debug), nil
+	case "<":
+		less, err := e.callOperator(op, left, right, n)
+		if err != nil {
+			return nil, err
+		}
+		return lang.NewBool(n.Content, less, n.Debug), nil
+	}
+
+	method, ok := operatorMethods[op]
+	if !ok || left.Type() != lang.TInstance || left.Method(method) == nil {
+		return nil, Error(ErrUnsupportedOperator, n.Debug, fmt.Sprintf("%s %s %s", lang.TypeOf(left), op, lang.TypeOf(right)))
+	}
+
+	value, err := callMagicMethod(method, left.Method(method), n.Debug, right)
+	if err != nil {
+		return nil, err
+	}
+
+	if value == nil {
+		return lang.NewNil(n.Content, n.Debug), nil
+	}
+	return value, nil
+}
+
+// applyUnaryOperator applies a unary operator to a value
+func (e *Executer) applyUnaryOperator(op string, value lang.Object, n *models.Node) (lang.Object, error) {
+	if value.Type() == lang.TInstance {
+		return nil, Error(ErrUnsupportedOperator, n.Debug, fmt.Sprintf("%s%s", op, lang.TypeOf(value)))
+	}
+
+	return e.evaluateValues(fmt.Sprintf("%s value", op), map[string]any{
+		"value": operandValue(value),
+	}, n)
+}
+
+// callOperator calls the magic method of the operator on the left instance and expects a boolean
+func (e *Executer) callOperator(op string, left, right lang.Object, n *models.Node) (bool, error) {
+	method := operatorMethods[op]
+	if left.Type() != lang.TInstance || left.Method(method) == nil {
+		return false, Error(ErrUnsupportedOperator, n.Debug, fmt.Sprintf("%s %s %s", lang.TypeOf(left), op, lang.TypeOf(right)))
+	}
+
+	value, err := callMagicMethod(method, left.Method(method), n.Debug, right)
+	if err != nil {
+		return false, err
+	}
+
+	if value == nil || value.Type() != lang.TBool {
+		return false, errs.WithDebug(Error(ErrExpectedBoolean, nil, fnErr(method)), n.Debug)
+	}
+
+	return value.Value().(bool), nil
+}
+
+// instancesEqual compares two values with `$eq`, falling back to identity
+func (e *Executer) instancesEqual(left, right lang.Object, n *models.Node) (bool, error) {
+	if left.Type() != lang.TInstance {
+		left, right = right, left
+	}
+
+	if left.Method("$eq") == nil {
+		instance, ok := left.(*lang.Instance)
+		return ok && instance.Same(right) || left == right, nil
+	}
+
+	return e.callOperator("==", left, right, n)
+}
+
+// evaluateValues evaluates a small expression with the given parameters
+func (e *Executer) evaluateValues(expression string, args map[string]any, n *models.Node) (lang.Object, error) {
+	expr, err := govaluate.NewEvaluableExpression(expression)
+	if err != nil {
+		return nil, Error(err, n.Debug, expression)
+	}
+
+	result, err := expr.Evaluate(args)
+	if err != nil {
+		return nil, errs.WithDebug(Error(err, nil, expression), n.Debug)
+	}
+
+	return e.objectFromResult(result, n)
+}
+
+// operandValue returns the value of an object as the expression evaluator expects it
+func operandValue(obj lang.Object) any {
+	if obj.Type() == lang.TList {
+		return obj
+	}
+	return obj.Value()
+}
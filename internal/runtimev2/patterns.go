@@ -0,0 +1,297 @@
+package runtimev2
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/flarelang/flare/internal/errs"
+	"github.com/flarelang/flare/internal/models"
+	"github.com/flarelang/flare/internal/tokens"
+	"github.com/flarelang/flare/lang"
+)
+
+// destructure matches the value against the pattern and collects the bound names.
+// It returns an ErrPatternMismatch error if the value does not match.
+func (e *Executer) destructure(pattern *models.Node, value lang.Object, bindings map[string]lang.Object) error {
+	switch pattern.Type {
+	case tokens.Else:
+		return nil
+	case tokens.Identifier:
+		if pattern.Reference {
+			expected, err := e.GetVariable(pattern.Content)
+			if err != nil {
+				return errs.WithDebug(err, pattern.Debug)
+			}
+
+			if !equalObjects(expected, value) {
+				return mismatchErr(pattern, fmt.Sprintf("expected %s, got %s", expected.String(), value.String()))
+			}
+			return nil
+		}
+
+		if typ, ok := pattern.Value.(string); ok && !e.isOfType(value, typ) {
+			return mismatchErr(pattern, expectedErr(typ, lang.TypeOf(value)))
+		}
+
+		if pattern.Content != "_" {
+			bound := value.Copy()
+			bound.Rename(pattern.Content)
+			bindings[pattern.Content] = bound
+		}
+		return nil
+	case tokens.String, tokens.Number, tokens.Bool, tokens.Nil:
+		_, expected, err := e.createObjectFromNode(&models.Node{
+			Type:         pattern.Type,
+			VariableType: e.getVariableTypeFromType(pattern),
+			Value:        pattern.Value,
+			Debug:        pattern.Debug,
+		})
+		if err != nil {
+			return err
+		}
+
+		if !equalObjects(expected, value) {
+			return mismatchErr(pattern, expectedErr(expected.String(), value.String()))
+		}
+		return nil
+	case tokens.List:
+		return e.destructureList(pattern, value, bindings)
+	case tokens.Array:
+		return e.destructureArray(pattern, value, bindings)
+	}
+
+	return Error(ErrUnhandledNodeType, pattern.Debug, pattern.Type)
+}
+
+// declareDestructured declares the variables bound by a `let [a, b] = value;` like node
+func (e *Executer) declareDestructured(node *models.Node) error {
+	_, value, err := e.createObjectFromNode(&models.Node{
+		Type:         tokens.Let,
+		VariableType: node.VariableType,
+		Value:        node.Value,
+		Children:     node.Children,
+		Debug:        node.Debug,
+	})
+	if err != nil {
+		return errs.WithDebug(err, node.Debug)
+	}
+
+	bindings := make(map[string]lang.Object)
+	if err := e.destructure(node.Args[0], value, bindings); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for name := range bindings {
+		if _, ok := e.objects[name]; ok {
+			return Error(ErrVariableRedeclared, node.Debug, name)
+		}
+	}
+
+	for name, obj := range bindings {
+		if node.Type == tokens.Const && obj.Type() != lang.TNil {
+			obj.Immute()
+		}
+		e.objects[name] = obj
+	}
+
+	return nil
+}
+
+func (e *Executer) destructureList(pattern *models.Node, value lang.Object, bindings map[string]lang.Object) error {
+	if value.Type() != lang.TList {
+		return mismatchErr(pattern, expectedErr(lang.TList, value.Type()))
+	}
+
+	items := value.Value().([]lang.Object)
+	elements := pattern.Children
+
+	var rest *models.Node
+	if len(elements) > 0 && elements[len(elements)-1].HasFlag("rest") {
+		rest = elements[len(elements)-1]
+		elements = elements[:len(elements)-1]
+	}
+
+	if len(items) < len(elements) || (rest == nil && len(items) != len(elements)) {
+		return mismatchErr(pattern, fmt.Sprintf("expected %d elements, got %d", len(elements), len(items)))
+	}
+
+	for i, element := range elements {
+		if err := e.destructure(element, items[i], bindings); err != nil {
+			return err
+		}
+	}
+
+	if rest != nil && rest.Content != "_" {
+		remaining := make([]lang.Object, 0, len(items)-len(elements))
+		for _, item := range items[len(elements):] {
+			remaining = append(remaining, item.Copy())
+		}
+		bindings[rest.Content] = lang.NewList(rest.Content, remaining, rest.Debug)
+	}
+
+	return nil
+}
+
+func (e *Executer) destructureArray(pattern *models.Node, value lang.Object, bindings map[string]lang.Object) error {
+	for _, entry := range pattern.Children {
+		var member lang.Object
+
+		switch v := value.(type) {
+		case *lang.Array:
+			member, _ = v.Access(entry.Content)
+		case *lang.Instance:
+			member = v.Variable(entry.Content)
+		default:
+			return mismatchErr(pattern, expectedErr(lang.TArray, value.Type()))
+		}
+
+		if member == nil {
+			return mismatchErr(entry, fmt.Sprintf("missing key %q", entry.Content))
+		}
+
+		if err := e.destructure(entry.Children[0], member, bindings); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// isOfType reports whether the object is of the named type.
+// The name can be a short type name like int, a definition or interface name or a full type string.
+func (e *Executer) isOfType(obj lang.Object, name string) bool {
+	switch name {
+	case "any":
+		return true
+	case "int":
+		return obj.Type() == lang.TInt
+	case "float":
+		return obj.Type() == lang.TFloat
+	case "string":
+		return obj.Type() == lang.TString
+	case "bool":
+		return obj.Type() == lang.TBool
+	case "list":
+		return obj.Type() == lang.TList
+	case "array":
+		return obj.Type() == lang.TArray
+	case "nil":
+		return obj.Type() == lang.TNil
+	case "function":
+		return obj.Type() == lang.TFnRef
+	case "instance":
+		return obj.Type() == lang.TInstance
+	}
+
+	if typ, err := e.GetVariable(name); err == nil {
+		inst, ok := obj.(*lang.Instance)
+
+		switch typ := typ.(type) {
+		case *lang.Definition:
+			return ok && inst.Definition().Extends(typ)
+		case *lang.Interface:
+			return ok && inst.Definition().Implements(typ)
+		case *lang.Enum:
+			value, ok := obj.(*lang.EnumValue)
+			return ok && typ.Same(value.Enum())
+		}
+	}
+
+	return lang.TypeOf(obj) == name || string(obj.Type()) == name
+}
+
+// equalObjects reports whether two objects hold the same value
+func equalObjects(a, b lang.Object) bool {
+	if isNumber(a) && isNumber(b) {
+		return toFloat(a) == toFloat(b)
+	}
+
+	if a.Type() != b.Type() {
+		return false
+	}
+
+	switch a.Type() {
+	case lang.TList:
+		left, right := a.Value().([]lang.Object), b.Value().([]lang.Object)
+		if len(left) != len(right) {
+			return false
+		}
+
+		for i := range left {
+			if !equalObjects(left[i], right[i]) {
+				return false
+			}
+		}
+		return true
+	case lang.TNil:
+		return true
+	}
+
+	return a.Value() == b.Value()
+}
+
+func isNumber(obj lang.Object) bool {
+	return obj.Type() == lang.TInt || obj.Type() == lang.TFloat
+}
+
+func toFloat(obj lang.Object) float64 {
+	if i, ok := obj.Value().(int); ok {
+		return float64(i)
+	}
+	f, _ := obj.Value().(float64)
+	return f
+}
+
+func mismatchErr(pattern *models.Node, detail string) error {
+	return errs.WithDebug(Error(ErrPatternMismatch, nil, detail), pattern.Debug)
+}
+
+// checkExhaustive returns an error if the subject of the match is an enum value
+// and the arms do not cover every value of the enum. Arms with guards do not count,
+// an `else` arm, a binding or a type pattern of the enum covers every value.
+func (e *Executer) checkExhaustive(node *models.Node, subject lang.Object) error {
+	value, ok := subject.(*lang.EnumValue)
+	if !ok {
+		return nil
+	}
+
+	covered := make(map[*lang.EnumValue]bool)
+	for _, arm := range node.Children {
+		if len(arm.Args) > 1 {
+			continue
+		}
+
+		pattern := arm.Args[0]
+		switch {
+		case pattern.Type == tokens.Else:
+			return nil
+		case pattern.Type == tokens.Identifier && pattern.Reference:
+			obj, err := e.GetVariable(pattern.Content)
+			if err != nil {
+				return errs.WithDebug(err, pattern.Debug)
+			}
+			if v, ok := obj.(*lang.EnumValue); ok {
+				covered[v.Value().(*lang.EnumValue)] = true
+			}
+		case pattern.Type == tokens.Identifier:
+			if typ, ok := pattern.Value.(string); !ok || e.isOfType(subject, typ) {
+				return nil
+			}
+		}
+	}
+
+	var missing []string
+	for _, v := range value.Enum().Values() {
+		if !covered[v] {
+			missing = append(missing, v.String())
+		}
+	}
+
+	if len(missing) > 0 {
+		return errs.WithDebug(Error(ErrMatchNotExhaustive, nil, "missing "+strings.Join(missing, ", ")), node.Debug)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package runtimev2
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const account = `
+	define Account {
+		let _balance = 0;
+
+		fn deposit(amount) {
+			this._balance = this._balance + amount;
+		}
+
+		fn _bonus() {
+			return 1;
+		}
+
+		fn balance() {
+			return this._balance + this._bonus();
+		}
+	}
+
+	const account = Account();
+	account.deposit(10);
+`
+
+func Test_PrivateThis(t *testing.T) {
+	ex := run(t, account+`let balance = account.balance();`)
+
+	assert.Equal(t, 11, value(t, ex, "balance"), "private members must be usable through this")
+}
+
+func Test_PrivateInstance(t *testing.T) {
+	tests := map[string]string{
+		"read":        `let balance = account._balance;`,
+		"write":       `account._balance = 100;`,
+		"call":        `account._bonus();`,
+		"method":      `let bonus = account._bonus;`,
+		"nested read": `let wrapper = array{account: account}; let balance = wrapper.account._balance;`,
+	}
+
+	for name, src := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := writeFiles(t, map[string]string{"main.fl": account + src})
+
+			err := runFile(t, newRuntime(t), filepath.Join(dir, "main.fl"))
+			assert.ErrorIs(t, err, ErrPrivateMember)
+		})
+	}
+}
+
+func Test_PrivateOtherInstance(t *testing.T) {
+	ex := run(t, `
+		define Account {
+			let _balance = 0;
+
+			fn construct(balance) {
+				this._balance = balance;
+			}
+
+			fn _bonus() {
+				return 1;
+			}
+
+			fn richer(other) {
+				return this._balance > other._balance;
+			}
+
+			fn bonusOf(other) {
+				return other._bonus();
+			}
+		}
+
+		define Savings extends Account {}
+
+		const poor = Account(1);
+		const rich = Account(5);
+		const savings = Savings(3);
+
+		let richer = rich.richer(poor);
+		let bonus = poor.bonusOf(rich);
+		let derived = poor.richer(savings);
+	`)
+
+	assert.Equal(t, true, value(t, ex, "richer"), "methods must use the private members of other instances of their definition")
+	assert.Equal(t, 1, value(t, ex, "bonus"))
+	assert.Equal(t, false, value(t, ex, "derived"), "methods must use the private members of instances of derived definitions")
+
+	_, err := execute(t, `
+		define Account {
+			let _balance = 0;
+		}
+
+		define Other {
+			fn peek(account) {
+				return account._balance;
+			}
+		}
+
+		Other().peek(Account());
+	`)
+	assert.ErrorIs(t, err, ErrPrivateMember, "methods of other definitions must not use private members")
+}
+
+func Test_PrivateNamespace(t *testing.T) {
+	lib := `
+		namespace lib;
+
+		let _count = 1;
+
+		fn _hidden() {
+			return _count;
+		}
+
+		fn visible() {
+			return _hidden() + 1;
+		}
+	`
+
+	dir := writeFiles(t, map[string]string{
+		"lib.fl": lib,
+		"main.fl": `
+			namespace main;
+			import("lib.fl");
+			use lib;
+			use (visible) from lib;
+
+			let a = lib.visible();
+			let b = visible();
+		`,
+	})
+
+	r := newRuntime(t)
+	assert.NoError(t, runFile(t, r, filepath.Join(dir, "main.fl")))
+	assert.Equal(t, 2, value(t, r.executers["main"], "a"), "private members must be usable inside their namespace")
+	assert.Equal(t, 2, value(t, r.executers["main"], "b"))
+
+	tests := map[string]string{
+		"call":             `use lib; lib._hidden();`,
+		"read":             `use lib; let count = lib._count;`,
+		"selective":        `use (_hidden) from lib; _hidden();`,
+		"selective alias":  `use (_count as count) from lib; println(count);`,
+		"selective method": `use (_hidden as hidden) from lib; hidden();`,
+	}
+
+	for name, src := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := writeFiles(t, map[string]string{
+				"lib.fl":  lib,
+				"main.fl": `namespace main; import("lib.fl"); ` + src,
+			})
+
+			err := runFile(t, newRuntime(t), filepath.Join(dir, "main.fl"))
+			assert.ErrorIs(t, err, ErrPrivateMember)
+		})
+	}
+}
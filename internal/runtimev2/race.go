@@ -0,0 +1,98 @@
+package runtimev2
+
+import (
+	"strings"
+
+	"github.com/flarelang/flare/internal/models"
+	"github.com/flarelang/flare/internal/race"
+	"github.com/flarelang/flare/lang"
+)
+
+// raceRead records a read of the variable for the race detector
+func (e *Executer) raceRead(name string, debug *models.Debug) {
+	e.raceAccess(name, debug, false)
+}
+
+// raceWrite records a write of the variable for the race detector
+func (e *Executer) raceWrite(name string, debug *models.Debug) {
+	e.raceAccess(name, debug, true)
+}
+
+func (e *Executer) raceAccess(name string, debug *models.Debug, write bool) {
+	if e.runtime.race == nil {
+		return
+	}
+
+	thread := race.FromContext(e.context())
+	if thread == nil {
+		return
+	}
+
+	loc, ok := e.variableLocation(name)
+	if !ok {
+		return
+	}
+
+	if write {
+		thread.Write(loc, name, debug)
+	} else {
+		thread.Read(loc, name, debug)
+	}
+}
+
+// variableLocation finds the scope holding the variable, it follows the lookup of GetVariable,
+// so `this.count` inside a method and `counter.count` outside of it are the same variable
+func (e *Executer) variableLocation(name string) (race.Location, bool) {
+	if strings.Contains(name, ".") {
+		names := strings.Split(name, ".")
+		first, rest, last := names[0], strings.Join(names[1:], "."), names[len(names)-1]
+
+		if first == "this" {
+			if def := e.isInsideDefinition(e); def != nil {
+				return def.variableLocation(rest)
+			}
+			return race.Location{}, false
+		}
+
+		if exec, n, err := e.lookupNamespace(names); err == nil {
+			return exec.variableLocation(strings.Join(names[n:], "."))
+		}
+
+		owner, err := e.GetVariable(strings.Join(names[:len(names)-1], "."))
+		if err != nil {
+			return race.Location{}, false
+		}
+
+		if inst, ok := owner.(*lang.Instance); ok {
+			if ex, ok := inst.Executer().(*Executer); ok {
+				return ex.variableLocation(last)
+			}
+		}
+
+		return race.Location{Holder: owner, Name: last}, true
+	}
+
+	for ex := e; ex != nil; ex = ex.parent {
+		ex.mu.RLock()
+		_, ok := ex.objects[name]
+		ex.mu.RUnlock()
+
+		if ok {
+			return race.Location{Holder: ex, Name: name}, true
+		}
+
+		if holder, _ := ex.inheritedObject(name); holder != nil {
+			return race.Location{Holder: holder, Name: name}, true
+		}
+
+		if exec, member, ok, err := ex.lookupMember(name); ok && err == nil {
+			return exec.variableLocation(member)
+		}
+
+		if ex.scope != ExecuterScopeBlock && ex.scope != ExecuterScopeFunction {
+			break
+		}
+	}
+
+	return race.Location{}, false
+}
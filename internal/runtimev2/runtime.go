@@ -1,6 +1,7 @@
 package runtimev2
 
 import (
+	"context"
 	"fmt"
 	"io/fs"
 	"os"
@@ -12,6 +13,7 @@ import (
 	"github.com/flarelang/flare/internal/errs"
 	"github.com/flarelang/flare/internal/models"
 	"github.com/flarelang/flare/internal/modules"
+	"github.com/flarelang/flare/internal/race"
 	"github.com/flarelang/flare/internal/state"
 	"github.com/flarelang/flare/internal/tokens"
 	"github.com/flarelang/flare/lang"
@@ -20,6 +22,9 @@ import (
 
 const PackageDirectory = ".flmod"
 
+// SearchPathVariable is the environment variable listing the directories of shared imports
+const SearchPathVariable = "FLARE_PATH"
+
 // RuntimeMode is the runtime mode
 type Runtime struct {
 	// functions are a map of function names to function objects
@@ -35,8 +40,23 @@ type Runtime struct {
 	// packages is a map of package names to runtime
 	packages map[string]*Runtime
 
+	// imports are the results of the imported files by their absolute path
+	imports map[string]lang.Object
+	// running are the files being executed by their absolute path
+	running map[string]*fileRun
+	// importing are the imports in progress, the imported files by the importing file,
+	// counted because threads can import the same file from the same file at once
+	importing map[string]map[string]int
+	// searchPaths are the directories where imports are looked up after the directory of the importing file
+	searchPaths []string
+
 	stateProvider *state.Provider
 
+	// race is the race detector, it is nil if race detection is disabled
+	race *race.Detector
+	// ctx is the context of the main program
+	ctx context.Context
+
 	mu sync.RWMutex
 }
 
@@ -48,6 +68,10 @@ func New(provider *state.Provider) (*Runtime, error) {
 	r := &Runtime{
 		executers:      make(map[string]*Executer),
 		packages:       make(map[string]*Runtime),
+		imports:        make(map[string]lang.Object),
+		running:        make(map[string]*fileRun),
+		importing:      make(map[string]map[string]int),
+		searchPaths:    filepath.SplitList(os.Getenv(SearchPathVariable)),
 		builtinModules: make(map[string]lang.Module, len(modules)),
 		stateProvider:  provider,
 	}
@@ -64,6 +88,27 @@ func New(provider *state.Provider) (*Runtime, error) {
 	return r, nil
 }
 
+// WithSearchPaths adds directories where imports are looked up, after the paths of FLARE_PATH
+func (r *Runtime) WithSearchPaths(paths ...string) *Runtime {
+	r.searchPaths = append(r.searchPaths, paths...)
+	return r
+}
+
+// WithRaceDetector enables race detection, the main program runs as the main thread of the detector
+func (r *Runtime) WithRaceDetector(d *race.Detector) *Runtime {
+	r.race = d
+	r.ctx = race.NewContext(context.Background(), d.Main())
+	return r
+}
+
+// context returns the context of the main program
+func (r *Runtime) context() context.Context {
+	if r.ctx == nil {
+		return context.Background()
+	}
+	return r.ctx
+}
+
 // Execute executes the given nodes
 func (r *Runtime) Execute(nodes []*models.Node) (lang.Object, error) {
 	namespace, nodes, err := r.GetNamespace(nodes)
@@ -110,7 +155,16 @@ func (r *Runtime) Exec(scope ExecuterScope, parent *Executer, namespace string,
 		r.mu.Unlock()
 	}
 
-	return ex.Execute(nodes)
+	ret, err := ex.Execute(nodes)
+	if err = ex.runDefers(err); err != nil {
+		return nil, err
+	}
+
+	if err := escapedLoopSignal(ret); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
 }
 
 // GetNamespaceExecuter gets the executer for the given namespace
@@ -16,19 +16,16 @@ import (
 	"go.uber.org/zap"
 )
 
+// importer imports a file or every file of a directory, the path is resolved relative to the importing file
+// and then in the search paths. A file is executed only once, later imports return its first result.
 func (r *Runtime) importer(filename string, dg *models.Debug) (lang.Object, error) {
 	zap.L().Info("importing file", zap.String("filename", filename))
 
-	var rootDir string
-	if dg != nil && dg.File != "" {
-		rootDir = filepath.Dir(dg.File)
-	} else {
-		rootDir = "." // Use current directory as default
+	path, err := r.resolveImport(filename, dg)
+	if err != nil {
+		return nil, errs.WithDebug(err, dg)
 	}
 
-	path := filepath.Join(rootDir, filename)
-	path = filepath.Clean(path)
-
 	fileInfo, err := os.Stat(path)
 	if err != nil {
 		return nil, errs.WithDebug(err, dg)
@@ -41,13 +38,7 @@ func (r *Runtime) importer(filename string, dg *models.Debug) (lang.Object, erro
 			}
 
 			if !info.IsDir() && filepath.Ext(filePath) == ".fl" {
-				// Calculate relative path correctly
-				relPath, err := filepath.Rel(rootDir, filePath)
-				if err != nil {
-					return err
-				}
-
-				_, err = r.importer(relPath, dg)
+				_, err = r.importFile(filePath, dg)
 				if err != nil {
 					return err
 				}
@@ -61,6 +52,52 @@ func (r *Runtime) importer(filename string, dg *models.Debug) (lang.Object, erro
 		return nil, nil
 	}
 
+	return r.importFile(path, dg)
+}
+
+// resolveImport finds the imported file, first next to the importing file, then in the search paths
+func (r *Runtime) resolveImport(filename string, dg *models.Debug) (string, error) {
+	if filepath.IsAbs(filename) {
+		return filepath.Clean(filename), nil
+	}
+
+	var rootDir string
+	if dg != nil && dg.File != "" {
+		rootDir = filepath.Dir(dg.File)
+	} else {
+		rootDir = "." // Use current directory as default
+	}
+
+	path := filepath.Clean(filepath.Join(rootDir, filename))
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+
+	for _, dir := range r.searchPaths {
+		candidate := filepath.Join(dir, filename)
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, nil
+		}
+	}
+
+	// the error of the relative path tells the most about a missing file
+	return path, nil
+}
+
+// importFile executes the file once, later imports return the result of the first one
+func (r *Runtime) importFile(path string, dg *models.Debug) (lang.Object, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, errs.WithDebug(err, dg)
+	}
+
+	r.mu.RLock()
+	imported, ok := r.imports[abs]
+	r.mu.RUnlock()
+	if ok {
+		return imported, nil
+	}
+
 	file, err := os.Open(path)
 	if err != nil {
 		return nil, errs.WithDebug(err, dg)
@@ -90,7 +127,13 @@ func (r *Runtime) importer(filename string, dg *models.Debug) (lang.Object, erro
 	}
 
 	cache.Store(path, b, nodes)
-	ret, err := r.Execute(nodes)
+
+	var from string
+	if dg != nil && dg.File != "" {
+		from, _ = filepath.Abs(dg.File)
+	}
+
+	ret, err := r.executeFile(abs, from, nodes)
 	if err != nil {
 		return nil, errs.WithDebug(err, dg)
 	}
@@ -98,6 +141,135 @@ func (r *Runtime) importer(filename string, dg *models.Debug) (lang.Object, erro
 	return ret, nil
 }
 
+// fileRun is a running file, the imports of the file while it runs wait for it to finish
+type fileRun struct {
+	done chan struct{}
+	ret  lang.Object
+	err  error
+}
+
+// ExecuteFile executes the nodes of the file. The files are keyed by their absolute path,
+// a file runs only once and an import of a file that is importing itself is reported as a cycle.
+func (r *Runtime) ExecuteFile(path string, nodes []*models.Node) (lang.Object, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.executeFile(abs, "", nodes)
+}
+
+// executeFile executes the file imported by the from file. If the file is already running, like when
+// threads import it at the same time, it waits for the first run instead of running the file again.
+func (r *Runtime) executeFile(abs, from string, nodes []*models.Node) (lang.Object, error) {
+	r.mu.Lock()
+	if imported, ok := r.imports[abs]; ok {
+		r.mu.Unlock()
+		return imported, nil
+	}
+
+	// an import that waits for a file which is waiting for the importing file never finishes,
+	// in one thread or across threads
+	if chain := r.importCycle(abs, from); chain != nil {
+		r.mu.Unlock()
+		return nil, Error(ErrImportCycle, nil, importChain(chain))
+	}
+
+	if from != "" {
+		r.addImporting(from, abs)
+		defer r.removeImporting(from, abs)
+	}
+
+	if run, ok := r.running[abs]; ok {
+		r.mu.Unlock()
+
+		<-run.done
+		return run.ret, run.err
+	}
+
+	run := &fileRun{done: make(chan struct{})}
+	r.running[abs] = run
+	r.mu.Unlock()
+
+	run.ret, run.err = r.Execute(nodes)
+
+	r.mu.Lock()
+	delete(r.running, abs)
+	if run.err == nil {
+		r.imports[abs] = run.ret
+	}
+	r.mu.Unlock()
+	close(run.done)
+
+	return run.ret, run.err
+}
+
+// addImporting records that the from file waits for the import of abs, r.mu must be held
+func (r *Runtime) addImporting(from, abs string) {
+	if r.importing[from] == nil {
+		r.importing[from] = make(map[string]int)
+	}
+	r.importing[from][abs]++
+}
+
+// removeImporting removes an import recorded by addImporting
+func (r *Runtime) removeImporting(from, abs string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.importing[from][abs]--; r.importing[from][abs] == 0 {
+		delete(r.importing[from], abs)
+	}
+	if len(r.importing[from]) == 0 {
+		delete(r.importing, from)
+	}
+}
+
+// importCycle returns the files from abs to the from file and back to abs, if abs is waiting for
+// the imports that lead to the from file. It returns nil if the import of abs would not wait for itself.
+func (r *Runtime) importCycle(abs, from string) []string {
+	if from == "" {
+		return nil
+	}
+
+	visited := make(map[string]bool)
+
+	var walk func(file string) []string
+	walk = func(file string) []string {
+		if file == from {
+			return []string{file, abs}
+		}
+		if visited[file] {
+			return nil
+		}
+		visited[file] = true
+
+		for next := range r.importing[file] {
+			if chain := walk(next); chain != nil {
+				return append([]string{file}, chain...)
+			}
+		}
+		return nil
+	}
+
+	return walk(abs)
+}
+
+// importChain formats the files of an import cycle relative to the working directory
+func importChain(chain []string) string {
+	wd, _ := os.Getwd()
+
+	names := make([]string, len(chain))
+	for i, path := range chain {
+		names[i] = path
+		if rel, err := filepath.Rel(wd, path); err == nil && !strings.HasPrefix(rel, "..") {
+			names[i] = rel
+		}
+	}
+
+	return strings.Join(names, " -> ")
+}
+
 func (r *Runtime) evaler(code string) (lang.Object, error) {
 	lx := lexer.New("<eval>")
 	ts, err := lx.Parse(strings.NewReader(code))
@@ -0,0 +1,151 @@
+package runtimev2
+
+import (
+	"testing"
+
+	"github.com/flarelang/flare/internal/modules/thread"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Select(t *testing.T) {
+	ex := run(t, `
+		use thread;
+
+		let empty = thread.portal();
+		let jobs = thread.portal();
+		jobs.send("job");
+
+		let {index, value, ok} = thread.select([empty, jobs]);
+
+		let full = thread.portal(1);
+		full.send(1);
+		let results = thread.portal(1);
+		let sent = thread.select([thread.sendCase(full, 2), thread.sendCase(results, 3)]);
+		let sentIndex = sent.index;
+		let received = results.receive();
+	`)
+
+	assert.Equal(t, 1, value(t, ex, "index"), "select must choose the case that can proceed")
+	assert.Equal(t, "job", value(t, ex, "value"))
+	assert.Equal(t, true, value(t, ex, "ok"))
+	assert.Equal(t, 1, value(t, ex, "sentIndex"), "select must send to the portal that has room")
+	assert.Equal(t, 3, value(t, ex, "received"))
+}
+
+func Test_SelectTimeout(t *testing.T) {
+	ex := run(t, `
+		use thread;
+
+		let jobs = thread.portal();
+		let {index, value, ok} = thread.select([jobs], 10);
+		let none = thread.select([], 0).index;
+	`)
+
+	assert.Equal(t, -1, value(t, ex, "index"), "select must return -1 on timeout")
+	assert.Nil(t, value(t, ex, "value"))
+	assert.Equal(t, false, value(t, ex, "ok"))
+	assert.Equal(t, -1, value(t, ex, "none"))
+
+	_, err := execute(t, `
+		use thread;
+		thread.select([]);
+	`)
+	assert.ErrorContains(t, err, "block forever", "select without cases and timeout must fail")
+}
+
+func Test_SelectClosed(t *testing.T) {
+	ex := run(t, `
+		use thread;
+
+		let jobs = thread.portal();
+		jobs.send("last");
+		jobs.close();
+
+		let first = thread.select([jobs]);
+		let second = thread.select([jobs]);
+		let last = first.value;
+		let firstOk = first.ok;
+		let secondOk = second.ok;
+		let secondIndex = second.index;
+	`)
+
+	assert.Equal(t, "last", value(t, ex, "last"), "the buffered messages of a closed portal must be received")
+	assert.Equal(t, true, value(t, ex, "firstOk"))
+	assert.Equal(t, false, value(t, ex, "secondOk"), "a closed and empty portal must be chosen with ok false")
+	assert.Equal(t, 0, value(t, ex, "secondIndex"))
+
+	_, err := execute(t, `
+		use thread;
+		let jobs = thread.portal();
+		jobs.close();
+		thread.select([thread.sendCase(jobs, 1)]);
+	`)
+	assert.ErrorIs(t, err, thread.ErrPortalClosed, "sending to a closed portal must fail")
+}
+
+func Test_PortalTimedReceive(t *testing.T) {
+	ex := run(t, `
+		use thread;
+
+		let jobs = thread.portal();
+		let nothing = jobs.tryReceive();
+		let timedOut = jobs.receiveTimeout(10);
+
+		jobs.send(1);
+		let tried = jobs.tryReceive();
+
+		thread.spawn(fn() {
+			thread.sleep(10);
+			jobs.send(2);
+		});
+		let waited = jobs.receiveTimeout(1000);
+
+		let nothingOk = nothing.ok;
+		let timedOutOk = timedOut.ok;
+		let triedValue = tried.value;
+		let waitedValue = waited.value;
+	`)
+
+	assert.Equal(t, false, value(t, ex, "nothingOk"), "tryReceive must not block on an empty portal")
+	assert.Equal(t, false, value(t, ex, "timedOutOk"), "receiveTimeout must return ok false on timeout")
+	assert.Equal(t, 1, value(t, ex, "triedValue"))
+	assert.Equal(t, 2, value(t, ex, "waitedValue"), "receiveTimeout must wait for a message")
+}
+
+func Test_PortalClosed(t *testing.T) {
+	ex := run(t, `
+		use thread;
+
+		let jobs = thread.portal();
+		jobs.send("last");
+		let open = jobs.closed();
+		jobs.close();
+
+		let closed = jobs.closed();
+		let last = jobs.receive();
+		let after = jobs.receive();
+		let timed = jobs.receiveTimeout(1000).ok;
+	`)
+
+	assert.Equal(t, false, value(t, ex, "open"))
+	assert.Equal(t, true, value(t, ex, "closed"))
+	assert.Equal(t, "last", value(t, ex, "last"), "the buffered messages of a closed portal must be received")
+	assert.Nil(t, value(t, ex, "after"), "receive on a closed and empty portal must return nil")
+	assert.Equal(t, false, value(t, ex, "timed"), "receiveTimeout must not wait on a closed portal")
+
+	tests := map[string]string{
+		"send":  `jobs.send(1);`,
+		"close": `jobs.close();`,
+	}
+
+	for name, src := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := execute(t, `
+				use thread;
+				let jobs = thread.portal();
+				jobs.close();
+			`+src)
+			assert.ErrorIs(t, err, thread.ErrPortalClosed)
+		})
+	}
+}
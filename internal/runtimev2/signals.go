@@ -0,0 +1,59 @@
+package runtimev2
+
+import (
+	"github.com/flarelang/flare/internal/models"
+	"github.com/flarelang/flare/internal/tokens"
+	"github.com/flarelang/flare/lang"
+)
+
+// loopSignal is the value produced by break and continue statements.
+// It travels up through block executers the same way a return value does,
+// until the closest loop consumes it.
+type loopSignal struct {
+	lang.Nil
+
+	token tokens.TokenType
+	debug *models.Debug
+}
+
+func newLoopSignal(node *models.Node) *loopSignal {
+	return &loopSignal{
+		token: node.Type,
+		debug: node.Debug,
+	}
+}
+
+func (s *loopSignal) Debug() *models.Debug {
+	return s.debug
+}
+
+func (s *loopSignal) String() string {
+	return s.token.String()
+}
+
+// isBreak reports whether the signal stops the loop
+func (s *loopSignal) isBreak() bool {
+	return s.token == tokens.Break
+}
+
+// loopResult interprets the result of a single loop iteration.
+// It reports whether the loop has to stop and the value the loop returns.
+func loopResult(ret lang.Object, err error) (bool, lang.Object, error) {
+	if err != nil {
+		return true, nil, err
+	}
+
+	if sig, ok := ret.(*loopSignal); ok {
+		return sig.isBreak(), nil, nil
+	}
+
+	return ret != nil, ret, nil
+}
+
+// escapedLoopSignal returns an error if a break or continue left the loop body
+func escapedLoopSignal(ret lang.Object) error {
+	if sig, ok := ret.(*loopSignal); ok {
+		return Error(ErrLoopControlOutsideLoop, sig.debug)
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package runtimev2
+
+import (
+	"testing"
+
+	"github.com/flarelang/flare/internal/modules/thread"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Mutex(t *testing.T) {
+	ex := run(t, `
+		use thread;
+
+		let count = 0;
+		const mu = thread.mutex();
+
+		spin i in 50 {
+			mu.lock();
+			count++;
+			mu.unlock();
+		}
+
+		let result = mu.withLock(fn() => "locked");
+
+		fn failing() {
+			fail("inside");
+		}
+
+		error err: mu.withLock(failing);
+		mu.lock();
+		mu.unlock();
+	`)
+
+	assert.Equal(t, 50, value(t, ex, "count"), "the mutex must guard the critical sections")
+	assert.Equal(t, "locked", value(t, ex, "result"), "withLock must return the result of the function")
+	assert.Contains(t, value(t, ex, "err"), "inside", "withLock must fail with the error of the function and unlock")
+
+	_, err := execute(t, `
+		use thread;
+		thread.mutex().unlock();
+	`)
+	assert.ErrorIs(t, err, thread.ErrNotLocked)
+}
+
+func Test_RWMutex(t *testing.T) {
+	ex := run(t, `
+		use thread;
+
+		let count = 0;
+		const mu = thread.rwmutex();
+
+		// readers do not block each other
+		mu.rlock();
+		mu.rlock();
+		mu.runlock();
+		mu.runlock();
+
+		spin i in 20 {
+			mu.lock();
+			count++;
+			mu.unlock();
+		}
+
+		let read = mu.withRLock(fn() => count);
+	`)
+
+	assert.Equal(t, 20, value(t, ex, "count"))
+	assert.Equal(t, 20, value(t, ex, "read"), "withRLock must return the result of the function")
+
+	tests := map[string]string{
+		"unlock":  `thread.rwmutex().unlock();`,
+		"runlock": `thread.rwmutex().runlock();`,
+	}
+
+	for name, src := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := execute(t, `use thread; `+src)
+			assert.ErrorIs(t, err, thread.ErrNotLocked)
+		})
+	}
+}
+
+func Test_WaitGroup(t *testing.T) {
+	ex := run(t, `
+		use thread;
+
+		const wg = thread.waitGroup();
+		const hits = thread.atomicInt(0);
+
+		wg.add(3);
+		for i in 3 {
+			thread.spawn(fn() {
+				thread.sleep(10);
+				hits.add(1);
+				wg.done();
+			});
+		}
+		wg.wait();
+
+		let count = hits.load();
+	`)
+
+	assert.Equal(t, 3, value(t, ex, "count"), "wait must block until every thread is done")
+
+	_, err := execute(t, `
+		use thread;
+		thread.waitGroup().done();
+	`)
+	assert.ErrorContains(t, err, "negative")
+}
+
+func Test_AtomicInt(t *testing.T) {
+	ex := run(t, `
+		use thread;
+
+		const n = thread.atomicInt(5);
+		let added = n.add(2);
+		n.store(10);
+		let stored = n.load();
+		let swapped = n.cas(10, 11);
+		let notSwapped = n.cas(10, 12);
+		let final = n.load();
+
+		const hits = thread.atomicInt(0);
+		spin i in 100 {
+			hits.add(1);
+		}
+		let count = hits.load();
+	`)
+
+	assert.Equal(t, 7, value(t, ex, "added"), "add must return the new value")
+	assert.Equal(t, 10, value(t, ex, "stored"))
+	assert.Equal(t, true, value(t, ex, "swapped"))
+	assert.Equal(t, false, value(t, ex, "notSwapped"), "cas must not swap if the value is not the old one")
+	assert.Equal(t, 11, value(t, ex, "final"))
+	assert.Equal(t, 100, value(t, ex, "count"))
+}
+
+func Test_Once(t *testing.T) {
+	ex := run(t, `
+		use thread;
+
+		const once = thread.once();
+		const calls = thread.atomicInt(0);
+		const results = thread.portal(10);
+
+		spin i in 10 {
+			results.send(once.do(fn() {
+				calls.add(1);
+				return i;
+			}));
+		}
+
+		let count = calls.load();
+		let first = results.receive();
+		let same = true;
+		for j in 9 {
+			if results.receive() != first {
+				same = false;
+			}
+		}
+
+		fn failing() {
+			fail("once failed");
+		}
+
+		const failingOnce = thread.once();
+		error firstErr: failingOnce.do(failing);
+		error secondErr: failingOnce.do(fn() => 1);
+	`)
+
+	assert.Equal(t, 1, value(t, ex, "count"), "do must call the function only once")
+	assert.Equal(t, true, value(t, ex, "same"), "do must return the result of the first call")
+	assert.Contains(t, value(t, ex, "secondErr"), "once failed", "do must return the error of the first call")
+}
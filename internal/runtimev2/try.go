@@ -0,0 +1,71 @@
+package runtimev2
+
+import (
+	"github.com/flarelang/flare/internal/models"
+	"github.com/flarelang/flare/internal/tokens"
+	"github.com/flarelang/flare/lang"
+	"go.uber.org/zap"
+)
+
+// handleTry handles try statements.
+// The first catch clause matching the error handles it, errors without a matching clause are raised again.
+// The finally block always runs, a return or loop control inside it replaces the result of the try.
+func (e *Executer) handleTry(node *models.Node) (lang.Object, error) {
+	ret, err := NewExecuter(ExecuterScopeBlock, e.runtime, e).Execute(node.Children)
+	if err != nil {
+		ret, err = e.handleCatch(node, err)
+	}
+
+	for _, clause := range node.Args {
+		if clause.Type != tokens.Finally {
+			continue
+		}
+
+		fret, ferr := NewExecuter(ExecuterScopeBlock, e.runtime, e).Execute(clause.Children)
+		if ferr != nil {
+			return nil, ferr
+		}
+
+		if fret != nil {
+			return fret, nil
+		}
+	}
+
+	return ret, err
+}
+
+// handleCatch runs the first catch clause matching the error
+func (e *Executer) handleCatch(node *models.Node, err error) (lang.Object, error) {
+	value := lang.ErrorValue(err)
+
+	zap.L().Debug("catching error", zap.Any("value", value))
+
+	for _, clause := range node.Args {
+		if clause.Type != tokens.Catch {
+			continue
+		}
+
+		if typ, ok := clause.Value.(string); ok && !e.isOfType(value, typ) {
+			continue
+		}
+
+		ex := NewExecuter(ExecuterScopeBlock, e.runtime, e)
+		if clause.Content != "" {
+			ex.BindObject(clause.Content, value)
+		}
+
+		return ex.Execute(clause.Children)
+	}
+
+	return nil, err
+}
+
+// handleThrow raises the value of a throw statement as an error
+func (e *Executer) handleThrow(node *models.Node) error {
+	value, err := e.evaluateExpression(node)
+	if err != nil {
+		return err
+	}
+
+	return Error(lang.Throw(value), node.Debug)
+}
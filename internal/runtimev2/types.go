@@ -0,0 +1,73 @@
+package runtimev2
+
+import (
+	"fmt"
+
+	"github.com/flarelang/flare/internal/models"
+	"github.com/flarelang/flare/lang"
+)
+
+// typeAnnotation returns the type annotation of a declaration, parameter or the return type of a function
+func typeAnnotation(n *models.Node, key string) string {
+	typ, _ := n.Map[key].(string)
+	return typ
+}
+
+// checkType returns an error if the object does not match the type, the type is a builtin type
+// like `int`, the name of a definition or an interface the object must be an instance of,
+// or the name of an enum the object must be a value of
+func (e *Executer) checkType(typ string, obj lang.Object, what string) error {
+	if builtin, ok := lang.TypeByName(typ); ok {
+		if builtin == lang.TAny || (obj != nil && obj.Type() == builtin) {
+			return nil
+		}
+		return Error(ErrTypeMismatch, nil, typeErr(what, typ, obj))
+	}
+
+	def, err := e.GetVariable(typ)
+	if err != nil {
+		return Error(ErrUnknownType, nil, typ)
+	}
+
+	inst, isInstance := obj.(*lang.Instance)
+
+	switch def := def.(type) {
+	case *lang.Definition:
+		if isInstance && inst.Definition().Extends(def) {
+			return nil
+		}
+	case *lang.Interface:
+		if isInstance && inst.Definition().Implements(def) {
+			return nil
+		}
+	case *lang.Enum:
+		if value, ok := obj.(*lang.EnumValue); ok && def.Same(value.Enum()) {
+			return nil
+		}
+	default:
+		return Error(ErrUnknownType, nil, typ)
+	}
+
+	return Error(ErrTypeMismatch, nil, typeErr(what, typ, obj))
+}
+
+// declareType remembers the type of the variable, later assignments are checked against it
+func (e *Executer) declareType(name string, typ string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.types[name] = typ
+}
+
+// declaredType returns the type the variable was declared with in this scope
+func (e *Executer) declaredType(name string) (string, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	typ, ok := e.types[name]
+	return typ, ok
+}
+
+func typeErr(what string, typ string, obj lang.Object) string {
+	return fmt.Sprintf("%s must be %s, got %s", what, typ, lang.TypeName(obj))
+}
@@ -26,6 +26,18 @@ const (
 	Throw
 	Spin
 	Error
+	Break
+	Continue
+	Match
+	Extends
+	Implements
+	Interface
+	Enum
+	Yield
+	Try
+	Catch
+	Finally
+	Defer
 
 	Number TokenType = iota + 1000
 	String
@@ -39,6 +51,7 @@ const (
 	TemplateLiteral
 	Array
 	ArrayKeyValuePair
+	MatchArm
 
 	Addition TokenType = iota + 10000
 	Subtraction
@@ -59,6 +72,15 @@ const (
 	Or
 	Not
 	Arrow
+	Pipe
+	Question
+	Coalesce
+	OptionalDot
+	AdditionAssign
+	SubtractionAssign
+	MultiplicationAssign
+	DivisionAssign
+	CoalesceAssign
 
 	LeftParenthesis TokenType = iota + 100000
 	RightParenthesis
@@ -224,6 +246,52 @@ func (t TokenType) String() string {
 		return "spin"
 	case Error:
 		return "error"
+	case Break:
+		return "break"
+	case Continue:
+		return "continue"
+	case Match:
+		return "match"
+	case Extends:
+		return "extends"
+	case Implements:
+		return "implements"
+	case Interface:
+		return "interface"
+	case Enum:
+		return "enum"
+	case Yield:
+		return "yield"
+	case Try:
+		return "try"
+	case Catch:
+		return "catch"
+	case Finally:
+		return "finally"
+	case Defer:
+		return "defer"
+	case MatchArm:
+		return "match arm"
+	case Arrow:
+		return "=>"
+	case Pipe:
+		return "|>"
+	case Question:
+		return "?"
+	case Coalesce:
+		return "??"
+	case OptionalDot:
+		return "?."
+	case AdditionAssign:
+		return "+="
+	case SubtractionAssign:
+		return "-="
+	case MultiplicationAssign:
+		return "*="
+	case DivisionAssign:
+		return "/="
+	case CoalesceAssign:
+		return "??="
 	default:
 		return "unkown"
 	}
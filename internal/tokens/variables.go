@@ -20,6 +20,9 @@ const (
 	EmptyReturnValue
 	ListVariable
 	ArrayVariable
+	MatchVariable
+	SpinVariable
+	ComprehensionVariable
 )
 
 func (v VariableType) String() string {
@@ -48,6 +51,14 @@ func (v VariableType) String() string {
 		return "return(empty)"
 	case ListVariable:
 		return "list"
+	case ArrayVariable:
+		return "array"
+	case MatchVariable:
+		return "match"
+	case SpinVariable:
+		return "spin"
+	case ComprehensionVariable:
+		return "comprehension"
 	default:
 		return "unknown"
 	}
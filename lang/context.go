@@ -0,0 +1,122 @@
+package lang
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/flarelang/flare/internal/race"
+)
+
+// Context is a cancellation context that scripts pass around,
+// functions run with it stop at the next statement after it is cancelled
+type Context struct {
+	Base
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func NewContext(ctx context.Context, cancel context.CancelFunc) *Context {
+	return &Context{
+		Base:   NewBase("context", nil),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Context returns the Go context
+func (c *Context) Context() context.Context {
+	return c.ctx
+}
+
+func (c *Context) Type() ObjType {
+	return TInstance
+}
+
+func (c *Context) TypeString() string {
+	return "context"
+}
+
+func (c *Context) Value() any {
+	return c
+}
+
+func (c *Context) Method(name string) Method {
+	switch name {
+	case "cancel":
+		return NewFunction(func(_ []Object) (Object, error) {
+			c.cancel()
+			return nil, nil
+		})
+	case "cancelled":
+		return NewFunction(func(_ []Object) (Object, error) {
+			return NewBool("cancelled", c.ctx.Err() != nil, nil), nil
+		})
+	case "error":
+		// error returns why the context was cancelled, or nil
+		return NewFunction(func(_ []Object) (Object, error) {
+			if err := c.ctx.Err(); err != nil {
+				return NewError("error", err, err.Error(), nil), nil
+			}
+			return NewNil("error", nil), nil
+		})
+	case "withTimeout":
+		// withTimeout creates a child context that is cancelled after the timeout or with its parent
+		return NewFunction(func(args []Object) (Object, error) {
+			ctx, cancel := context.WithTimeout(c.ctx, time.Duration(args[0].Value().(int))*time.Millisecond)
+			return NewContext(ctx, cancel), nil
+		}).WithTypeSafeArgs(TypeSafeArg{Name: "timeout", Type: TInt})
+	case "run":
+		// run calls the function with the context in the thread of the caller, it returns the result of the function
+		return NewContextFunction(func(caller context.Context, args []Object) (Object, error) {
+			fn := args[0].(*Fn).Fn
+			if len(fn.Args()) != 0 {
+				return nil, fmt.Errorf("run handler must have 0 arguments, got %d", len(fn.Args()))
+			}
+			return ExecuteContext(race.NewContext(c.ctx, race.FromContext(caller)), fn, nil)
+		}).WithTypeSafeArgs(TypeSafeArg{Name: "method", Type: TFnRef})
+	default:
+		return nil
+	}
+}
+
+func (c *Context) Methods() []string {
+	return []string{"cancel", "cancelled", "error", "withTimeout", "run"}
+}
+
+func (c *Context) Variable(variable string) Object {
+	switch variable {
+	default:
+		return nil
+	case "$addr":
+		return addr(c)
+	}
+}
+
+func (c *Context) Variables() []string {
+	return []string{"$addr"}
+}
+
+func (c *Context) SetVariable(_ string, _ Object) error {
+	return errNotImplemented
+}
+
+func (c *Context) String() string {
+	if c.ctx.Err() != nil {
+		return fmt.Sprintf("<Context %s cancelled>", addr(c))
+	}
+	return fmt.Sprintf("<Context %s>", addr(c))
+}
+
+func (c *Context) Copy() Object {
+	return c
+}
+
+// ExecuteContext executes the method with the context if it accepts one
+func ExecuteContext(ctx context.Context, m Method, args []Object) (Object, error) {
+	if cm, ok := m.(ContextMethod); ok {
+		return cm.ExecuteContext(ctx, args)
+	}
+	return m.Execute(args)
+}
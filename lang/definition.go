@@ -14,6 +14,11 @@ type Definition struct {
 
 	ex    Executer
 	nodes []*models.Node
+
+	// parent is the definition this definition extends
+	parent *Definition
+	// interfaces are the interfaces this definition implements
+	interfaces []*Interface
 }
 
 func NewDefinition(defName, name string, debug *models.Debug, nodes []*models.Node, ex Executer) *Definition {
@@ -25,6 +30,45 @@ func NewDefinition(defName, name string, debug *models.Debug, nodes []*models.No
 	}
 }
 
+// WithParent makes the definition extend the given definition
+func (d *Definition) WithParent(parent *Definition) *Definition {
+	d.parent = parent
+	return d
+}
+
+// WithInterfaces sets the interfaces the definition implements
+func (d *Definition) WithInterfaces(interfaces ...*Interface) *Definition {
+	d.interfaces = interfaces
+	return d
+}
+
+// Parent returns the definition this definition extends, or nil
+func (d *Definition) Parent() *Definition {
+	return d.parent
+}
+
+// Extends reports whether the definition is the other definition or inherits from it
+func (d *Definition) Extends(other *Definition) bool {
+	for def := d; def != nil; def = def.parent {
+		if def == other {
+			return true
+		}
+	}
+	return false
+}
+
+// Implements reports whether the definition or one of its parents implements the interface
+func (d *Definition) Implements(iface *Interface) bool {
+	for def := d; def != nil; def = def.parent {
+		for _, i := range def.interfaces {
+			if i == iface {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (d *Definition) Type() ObjType {
 	return TDefinition
 }
@@ -65,9 +109,42 @@ func (d *Definition) Copy() Object {
 	return d
 }
 
+// inheriter is implemented by executers that can fall back to the executer of a parent instance
+type inheriter interface {
+	Inherit(parent Executer)
+}
+
 func (d *Definition) NewInstance() (Object, error) {
+	inst, err := d.newInstance()
+	if err != nil {
+		return nil, err
+	}
+
+	for def := d; def != nil; def = def.parent {
+		for _, iface := range def.interfaces {
+			if err := iface.Check(inst); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return inst, nil
+}
+
+func (d *Definition) newInstance() (*Instance, error) {
 	exec := d.ex.GetNew()
 
+	if d.parent != nil {
+		parent, err := d.parent.newInstance()
+		if err != nil {
+			return nil, err
+		}
+
+		if inh, ok := exec.(inheriter); ok {
+			inh.Inherit(parent.ex)
+		}
+	}
+
 	_, err := exec.Execute(d.nodes)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,223 @@
+package lang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/flarelang/flare/internal/models"
+)
+
+// Enum is a fixed set of named values, declared with `enum Role { Admin, Editor }`
+type Enum struct {
+	Base
+
+	enumName string
+	values   []*EnumValue
+
+	// origin is the declared enum, copies of the enum share it
+	origin *Enum
+}
+
+// NewEnum creates an immutable enum without values, the values are added with Add
+func NewEnum(enumName, name string, debug *models.Debug) *Enum {
+	e := &Enum{
+		Base:     NewBase(name, debug),
+		enumName: strings.TrimLeft(enumName, "."),
+	}
+	e.origin = e
+	e.Immute()
+	return e
+}
+
+// Add adds a value to the enum, value is the associated value or nil
+func (e *Enum) Add(name string, value Object, debug *models.Debug) *EnumValue {
+	v := &EnumValue{
+		Base:    NewBase(name, debug),
+		enum:    e.origin,
+		member:  name,
+		ordinal: len(e.values),
+		value:   value,
+	}
+	v.Immute()
+
+	e.values = append(e.values, v)
+	return v
+}
+
+// Values returns the values of the enum in the order they were declared
+func (e *Enum) Values() []*EnumValue {
+	return e.values
+}
+
+// Same reports whether the object is a copy of the same enum
+func (e *Enum) Same(obj Object) bool {
+	other, ok := obj.(*Enum)
+	return ok && other.origin == e.origin
+}
+
+func (e *Enum) Type() ObjType {
+	return TEnum
+}
+
+func (e *Enum) TypeString() string {
+	return e.enumName
+}
+
+func (e *Enum) Value() any {
+	return e.origin
+}
+
+func (e *Enum) Method(name string) Method {
+	switch name {
+	case "values":
+		return NewFunction(func(args []Object) (Object, error) {
+			return NewList("values", e.objects(), e.debug), nil
+		}).WithDebug(e.debug)
+	}
+	return nil
+}
+
+func (e *Enum) Methods() []string {
+	return []string{"values"}
+}
+
+func (e *Enum) Variable(variable string) Object {
+	if variable == "length" {
+		return NewInteger("length", len(e.values), e.debug)
+	}
+
+	for _, v := range e.values {
+		if v.member == variable {
+			return v.Copy()
+		}
+	}
+	return nil
+}
+
+func (e *Enum) Variables() []string {
+	names := make([]string, len(e.values))
+	for i, v := range e.values {
+		names[i] = v.member
+	}
+	return names
+}
+
+func (e *Enum) SetVariable(name string, value Object) error {
+	return fmt.Errorf("cannot change %s.%s: enums are immutable", e.enumName, name)
+}
+
+func (e *Enum) String() string {
+	return fmt.Sprintf("<enum %s>", e.enumName)
+}
+
+func (e *Enum) Copy() Object {
+	c := *e
+	return &c
+}
+
+func (e *Enum) Iterator() Iterator {
+	i := 0
+	return IteratorFunc(func() (Object, bool, error) {
+		if i >= len(e.values) {
+			return nil, true, nil
+		}
+		i++
+		return e.values[i-1].Copy(), false, nil
+	})
+}
+
+func (e *Enum) objects() []Object {
+	objects := make([]Object, len(e.values))
+	for i, v := range e.values {
+		objects[i] = v.Copy()
+	}
+	return objects
+}
+
+// EnumValue is a value of an enum, copies of the same value are equal
+type EnumValue struct {
+	Base
+
+	enum    *Enum
+	member  string
+	ordinal int
+	value   Object
+}
+
+func (v *EnumValue) Type() ObjType {
+	return TEnumValue
+}
+
+func (v *EnumValue) TypeString() string {
+	return v.enum.enumName
+}
+
+// Value returns the declared value, it is the same for every copy of the value
+func (v *EnumValue) Value() any {
+	return v.enum.values[v.ordinal]
+}
+
+// Same reports whether the object is a copy of the same enum value
+func (v *EnumValue) Same(obj Object) bool {
+	other, ok := obj.(*EnumValue)
+	return ok && other.Value() == v.Value()
+}
+
+// Enum returns the enum the value belongs to
+func (v *EnumValue) Enum() *Enum {
+	return v.enum
+}
+
+// Member returns the name of the value in the enum
+func (v *EnumValue) Member() string {
+	return v.member
+}
+
+// Associated returns the associated value, or nil if the value was declared without one
+func (v *EnumValue) Associated() Object {
+	return v.value
+}
+
+func (v *EnumValue) Method(name string) Method {
+	return nil
+}
+
+func (v *EnumValue) Methods() []string {
+	return nil
+}
+
+func (v *EnumValue) Variable(variable string) Object {
+	switch variable {
+	case "name":
+		return NewString("name", v.member, v.debug)
+	case "ordinal":
+		return NewInteger("ordinal", v.ordinal, v.debug)
+	case "value":
+		if v.value == nil {
+			return NewNil("value", v.debug)
+		}
+		return v.value.Copy()
+	}
+	return nil
+}
+
+func (v *EnumValue) Variables() []string {
+	return []string{"name", "ordinal", "value"}
+}
+
+func (v *EnumValue) SetVariable(name string, value Object) error {
+	return fmt.Errorf("cannot change %s.%s.%s: enums are immutable", v.enum.enumName, v.member, name)
+}
+
+func (v *EnumValue) String() string {
+	name := v.enum.enumName
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	return name + "." + v.member
+}
+
+func (v *EnumValue) Copy() Object {
+	c := *v
+	return &c
+}
@@ -0,0 +1,205 @@
+package lang
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/flarelang/flare/internal/errs"
+	"github.com/flarelang/flare/internal/models"
+)
+
+// Error represents an error raised by the runtime or a builtin function,
+// it is the value bound by `catch` when no object was thrown
+type Error struct {
+	Base
+
+	err     error
+	message string
+}
+
+// NewError creates an error object, the message is shown to the user
+// while the error keeps the original error for errors.is and errors.as
+func NewError(name string, err error, message string, debug *models.Debug) Object {
+	return &Error{
+		Base:    NewBase(name, debug),
+		err:     err,
+		message: message,
+	}
+}
+
+func (e *Error) Type() ObjType {
+	return TError
+}
+
+func (*Error) TypeString() string {
+	return "error"
+}
+
+func (e *Error) Value() any {
+	return e
+}
+
+// Err returns the underlying Go error
+func (e *Error) Err() error {
+	return e.err
+}
+
+func (e *Error) Method(name string) Method {
+	return nil
+}
+
+func (e *Error) Methods() []string {
+	return nil
+}
+
+func (e *Error) Variable(variable string) Object {
+	switch variable {
+	default:
+		return nil
+	case "message":
+		return NewString("message", e.message, e.debug)
+	case "errors":
+		return NewList("errors", e.errors(), e.debug)
+	case "$addr":
+		return addr(e)
+	}
+}
+
+func (e *Error) Variables() []string {
+	return []string{"message", "errors", "$addr"}
+}
+
+// errors returns the values of the joined errors, or the error itself
+func (e *Error) errors() []Object {
+	joined, ok := e.err.(errs.JoinedError)
+	if !ok {
+		return []Object{e}
+	}
+
+	values := make([]Object, len(joined.Errors()))
+	for i, err := range joined.Errors() {
+		values[i] = ErrorValue(err)
+	}
+	return values
+}
+
+func (e *Error) SetVariable(_ string, _ Object) error {
+	return errNotImplemented
+}
+
+func (e *Error) String() string {
+	return e.message
+}
+
+func (e *Error) Copy() Object {
+	return e
+}
+
+// ThrowError is the error raised by a `throw` statement, it carries the thrown object
+type ThrowError struct {
+	Value Object
+}
+
+// Throw creates the error of a thrown object
+func Throw(value Object) error {
+	return &ThrowError{Value: value}
+}
+
+func (t *ThrowError) Error() string {
+	message := t.Value.String()
+	if msg := t.Value.Variable("message"); msg != nil && t.Value.Type() == TInstance {
+		message = msg.String()
+	}
+
+	if t.Value.Type() == TInstance {
+		return fmt.Sprintf("%s: %s", TypeOf(t.Value), message)
+	}
+	return message
+}
+
+// Unwrap returns the Go error of a thrown error or of the cause of the thrown object
+func (t *ThrowError) Unwrap() error {
+	if e, ok := t.Value.(*Error); ok {
+		return e.err
+	}
+
+	if t.Value.Type() != TInstance {
+		return nil
+	}
+
+	if cause := t.Value.Variable("cause"); cause != nil && cause.Type() != TNil {
+		return Throw(cause)
+	}
+	return nil
+}
+
+// ErrorCause returns the cause of an error value: the `cause` of thrown instances
+// or the wrapped error of runtime errors
+func ErrorCause(value Object) Object {
+	switch v := value.(type) {
+	case *Error:
+		err := v.err
+		for {
+			de, ok := err.(interface{ GetParentError() error })
+			if !ok {
+				break
+			}
+			err = de.GetParentError()
+		}
+
+		next := errors.Unwrap(err)
+		if next == nil {
+			return nil
+		}
+
+		if thrown, ok := next.(*ThrowError); ok {
+			return thrown.Value
+		}
+
+		// debug errors are shown without the source location
+		message := next
+		if de, ok := next.(interface{ GetParentError() error }); ok {
+			message = de.GetParentError()
+		}
+		return NewError("cause", next, message.Error(), v.debug)
+	case *Instance:
+		if cause := v.Variable("cause"); cause != nil && cause.Type() != TNil {
+			return cause
+		}
+	}
+
+	return nil
+}
+
+// ErrorValue converts an error into the value bound by catch.
+// Thrown objects are returned as they are, other errors become error objects.
+func ErrorValue(err error) Object {
+	// errors of concurrent or deferred code are caught together
+	if joined, ok := err.(errs.JoinedError); ok {
+		messages := make([]string, len(joined.Errors()))
+		for i, err := range joined.Errors() {
+			messages[i] = errorMessage(err)
+		}
+		return NewError("error", err, strings.Join(messages, "\n"), nil)
+	}
+
+	var thrown *ThrowError
+	if errors.As(err, &thrown) {
+		return thrown.Value
+	}
+
+	return NewError("error", err, errorMessage(err), nil)
+}
+
+// errorMessage returns the message of an error without the source location
+func errorMessage(err error) string {
+	message := err
+	var de errs.DebugError
+	if errors.As(err, &de) {
+		message = de.GetParentError()
+	}
+
+	// the message of runtime errors is shown without the prefix
+	return strings.TrimPrefix(message.Error(), errs.RuntimeError.Error()+" - ")
+}
@@ -1,6 +1,7 @@
 package lang
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/flarelang/flare/internal/models"
@@ -17,8 +18,14 @@ type Function struct {
 	typesafeArgs []TypeSafeArg
 	// variadicArg is the variadic argument of the function
 	variadicArg string
+	// defaults is a map of optional argument names to their default values
+	defaults map[string]DefaultFunc
 	// exec is the function to execute when the function is called
 	exec ExecFunc
+	// execCtx is used instead of exec by functions that receive the context of the caller
+	execCtx ContextExecFunc
+	// scopeCtx keeps a nil context for execCtx when the function is called without one
+	scopeCtx bool
 
 	debug *models.Debug
 }
@@ -30,6 +37,12 @@ type TypeSafeArg struct {
 
 type ExecFunc func(args []Object) (Object, error)
 
+// ContextExecFunc is the function of a method that receives the cancellation context of the caller
+type ContextExecFunc func(ctx context.Context, args []Object) (Object, error)
+
+// DefaultFunc creates the default value of an optional argument
+type DefaultFunc func() (Object, error)
+
 // NewFunction creates a new function method
 func NewFunction(exec ExecFunc) *Function {
 	return &Function{
@@ -37,6 +50,21 @@ func NewFunction(exec ExecFunc) *Function {
 	}
 }
 
+// NewContextFunction creates a function method that receives the cancellation context of the caller,
+// it gets a background context when it is called without one
+func NewContextFunction(exec ContextExecFunc) *Function {
+	return &Function{
+		execCtx: exec,
+	}
+}
+
+// WithScopeContext makes the function get a nil context when it is called without one,
+// so it can fall back to the context of the scope it was created in
+func (f *Function) WithScopeContext() *Function {
+	f.scopeCtx = true
+	return f
+}
+
 func (f *Function) WithArgs(args []string) *Function {
 	f.args = args
 	return f
@@ -52,6 +80,28 @@ func (f *Function) WithVariadicArg(arg string) *Function {
 	return f
 }
 
+// WithDefault makes the argument optional, the value is created on every call that omits it
+func (f *Function) WithDefault(arg string, value DefaultFunc) *Function {
+	if f.defaults == nil {
+		f.defaults = make(map[string]DefaultFunc)
+	}
+
+	f.defaults[arg] = value
+	return f
+}
+
+// WithSignatureOf copies the arguments, defaults and variadic argument of the given method
+func (f *Function) WithSignatureOf(m Method) *Function {
+	f.args = m.Args()
+
+	if fn, ok := m.(*Function); ok {
+		f.defaults = fn.defaults
+		f.variadicArg = fn.variadicArg
+	}
+
+	return f
+}
+
 func (f *Function) WithTypeSafeArgs(typesafeArgs ...TypeSafeArg) *Function {
 	var args = make([]string, len(typesafeArgs))
 
@@ -73,7 +123,13 @@ func (f *Function) Args() []string {
 	return f.args
 }
 
+// Execute executes the function without the context of a caller
 func (f *Function) Execute(args []Object) (Object, error) {
+	return f.ExecuteContext(nil, args)
+}
+
+// ExecuteContext executes the function with the cancellation context of the caller
+func (f *Function) ExecuteContext(ctx context.Context, args []Object) (Object, error) {
 	if len(f.typesafeArgs) == len(f.args) {
 		for i, name := range f.args {
 			if f.typesafeArgs[i].Type == TAny {
@@ -86,6 +142,12 @@ func (f *Function) Execute(args []Object) (Object, error) {
 		}
 	}
 
+	if f.execCtx != nil {
+		if ctx == nil && !f.scopeCtx {
+			ctx = context.Background()
+		}
+		return f.execCtx(ctx, args)
+	}
 	return f.exec(args)
 }
 
@@ -100,3 +162,17 @@ func (f *Function) HasVariadicArg() bool {
 func (f *Function) GetVariadicArg() string {
 	return f.variadicArg
 }
+
+func (f *Function) HasDefault(arg string) bool {
+	_, ok := f.defaults[arg]
+	return ok
+}
+
+func (f *Function) Default(arg string) (Object, error) {
+	value, ok := f.defaults[arg]
+	if !ok {
+		return nil, fmt.Errorf("argument %s has no default value", arg)
+	}
+
+	return value()
+}
@@ -47,6 +47,17 @@ func (i *Instance) Definition() *Definition {
 	return i.def
 }
 
+// Executer returns the executer holding the variables of the instance
+func (i *Instance) Executer() Executer {
+	return i.ex
+}
+
+// Same reports whether the object is a copy of the same instance
+func (i *Instance) Same(obj Object) bool {
+	other, ok := obj.(*Instance)
+	return ok && other.ex == i.ex
+}
+
 func (i *Instance) Method(name string) Method {
 	if name == "$init" {
 		construct, err := i.ex.GetMethod("construct")
@@ -64,7 +75,7 @@ func (i *Instance) Method(name string) Method {
 
 			_, err = construct.Execute(args)
 			return i, err
-		}).WithArgs(construct.Args()).WithDebug(i.debug)
+		}).WithSignatureOf(construct).WithDebug(i.debug)
 	}
 
 	if name == "$method" {
@@ -92,7 +103,12 @@ func (i *Instance) Variable(variable string) Object {
 		return addr(i)
 	}
 
-	obj, _ := i.ex.GetVariable(variable)
+	obj, err := i.ex.GetVariable(variable)
+	if err != nil && variable == "length" {
+		if method, err := i.ex.GetMethod("$len"); err == nil && len(method.Args()) == 0 {
+			obj, _ = method.Execute(nil)
+		}
+	}
 	return obj
 }
 
@@ -105,11 +121,13 @@ func (i *Instance) SetVariable(name string, value Object) error {
 }
 
 func (i *Instance) String() string {
-	method, err := i.ex.GetMethod("string")
-	if err == nil && len(method.Args()) == 0 {
-		val, err := method.Execute(nil)
-		if err == nil {
-			return val.String()
+	for _, name := range []string{"$string", "string"} {
+		method, err := i.ex.GetMethod(name)
+		if err == nil && len(method.Args()) == 0 {
+			val, err := method.Execute(nil)
+			if err == nil && val != nil {
+				return val.String()
+			}
 		}
 	}
 
@@ -0,0 +1,95 @@
+package lang
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/flarelang/flare/internal/models"
+)
+
+// InterfaceMethod is a method signature required by an interface
+type InterfaceMethod struct {
+	Name string
+	Args []string
+}
+
+// Interface is a set of methods and properties a definition has to provide
+type Interface struct {
+	Base
+
+	ifaceName string
+
+	methods    []InterfaceMethod
+	properties []string
+}
+
+func NewInterface(ifaceName, name string, debug *models.Debug, methods []InterfaceMethod, properties []string) *Interface {
+	return &Interface{
+		Base:       NewBase(name, debug),
+		ifaceName:  strings.TrimLeft(ifaceName, "."),
+		methods:    methods,
+		properties: properties,
+	}
+}
+
+func (i *Interface) Type() ObjType {
+	return TInterface
+}
+
+func (i *Interface) TypeString() string {
+	return i.ifaceName
+}
+
+func (i *Interface) Value() any {
+	return i
+}
+
+func (i *Interface) Method(name string) Method {
+	return nil
+}
+
+func (i *Interface) Methods() []string {
+	return nil
+}
+
+func (i *Interface) Variable(variable string) Object {
+	return nil
+}
+
+func (i *Interface) Variables() []string {
+	return nil
+}
+
+func (i *Interface) SetVariable(name string, value Object) error {
+	return errNotImplemented
+}
+
+func (i *Interface) String() string {
+	return fmt.Sprintf("<interface %s>", i.ifaceName)
+}
+
+func (i *Interface) Copy() Object {
+	return i
+}
+
+// Check returns an error if the object does not provide every method and property of the interface
+func (i *Interface) Check(obj Object) error {
+	for _, method := range i.methods {
+		m := obj.Method(method.Name)
+		if m == nil {
+			return fmt.Errorf("%s does not implement %s: missing method %s(%s)", TypeOf(obj), i.ifaceName, method.Name, strings.Join(method.Args, ", "))
+		}
+
+		if len(m.Args()) != len(method.Args) {
+			return fmt.Errorf("%s does not implement %s: method %s expects %d arguments, got %d", TypeOf(obj), i.ifaceName, method.Name, len(method.Args), len(m.Args()))
+		}
+	}
+
+	for _, property := range i.properties {
+		if obj.Variable(property) == nil {
+			return fmt.Errorf("%s does not implement %s: missing property %s", TypeOf(obj), i.ifaceName, property)
+		}
+	}
+
+	return nil
+}
@@ -9,13 +9,15 @@ import (
 
 type IOStream struct {
 	Base
-	reader io.Reader
+	reader   io.Reader
+	buffered *bufio.Reader
 }
 
 func NewIOStream(name string, r io.Reader) Object {
 	return &IOStream{
-		Base:   NewBase(name, nil),
-		reader: r,
+		Base:     NewBase(name, nil),
+		reader:   r,
+		buffered: bufio.NewReader(r),
 	}
 }
 
@@ -35,8 +37,7 @@ func (i *IOStream) Method(name string) Method {
 	switch name {
 	case "readLine":
 		return NewFunction(func(_ []Object) (Object, error) {
-			reader := bufio.NewReader(i.reader)
-			line, err := reader.ReadString('\n')
+			line, err := i.buffered.ReadString('\n')
 			if err != nil {
 				return nil, err
 			}
@@ -44,10 +45,9 @@ func (i *IOStream) Method(name string) Method {
 		})
 	case "readLines":
 		return NewFunction(func(_ []Object) (Object, error) {
-			reader := bufio.NewReader(i.reader)
 			lines := strings.Builder{}
 			for {
-				line, err := reader.ReadString('\n')
+				line, err := i.buffered.ReadString('\n')
 				if err != nil {
 					if err == io.EOF {
 						break
@@ -103,7 +103,24 @@ func (i *IOStream) String() string {
 
 func (i *IOStream) Copy() Object {
 	return &IOStream{
-		Base:   NewBase(i.name, nil),
-		reader: i.reader,
+		Base:     NewBase(i.name, nil),
+		reader:   i.reader,
+		buffered: i.buffered,
 	}
 }
+
+// Iterator reads the stream lazily line by line, without the line endings
+func (i *IOStream) Iterator() Iterator {
+	return IteratorFunc(func() (Object, bool, error) {
+		line, err := i.buffered.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			if err == io.EOF {
+				return nil, true, nil
+			}
+			return nil, false, err
+		}
+
+		line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+		return NewString("line", line, i.debug), false, nil
+	})
+}
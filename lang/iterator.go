@@ -0,0 +1,143 @@
+package lang
+
+import (
+	"fmt"
+	"strings"
+)
+
+// IteratorOf returns an iterator over the object.
+// Besides Iterable objects, every object with a `next` method
+// returning a value/done pair (see NewIteratorResult) can be iterated.
+func IteratorOf(obj Object) (Iterator, bool) {
+	if it, ok := obj.(Iterable); ok {
+		return it.Iterator(), true
+	}
+
+	if method := obj.Method("next"); method != nil && len(method.Args()) == 0 {
+		return &methodIterator{obj: obj, next: method}, true
+	}
+
+	return nil, false
+}
+
+// NewIteratorResult creates the value/done pair returned by `next` methods
+func NewIteratorResult(value Object, done bool) Object {
+	if value == nil {
+		value = NewNil("value", nil)
+	}
+
+	return NewArray("next", nil, []Object{
+		NewString("key", "value", nil),
+		NewString("key", "done", nil),
+	}, []Object{
+		value,
+		NewBool("done", done, nil),
+	})
+}
+
+// IteratorFunc adapts a function to an Iterator
+type IteratorFunc func() (Object, bool, error)
+
+func (f IteratorFunc) Next() (Object, bool, error) {
+	return f()
+}
+
+// NextMethod creates a `next` method that steps the iterator,
+// so Go objects can expose the same protocol as user definitions
+func NextMethod(it Iterator) Method {
+	return NewFunction(func(_ []Object) (Object, error) {
+		item, done, err := it.Next()
+		if err != nil {
+			return nil, err
+		}
+		return NewIteratorResult(item, done), nil
+	})
+}
+
+// methodIterator iterates over an object by calling its `next` method
+type methodIterator struct {
+	obj  Object
+	next Method
+}
+
+func (m *methodIterator) Next() (Object, bool, error) {
+	result, err := m.next.Execute(nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if result == nil || result.Type() != TArray {
+		return nil, false, fmt.Errorf("%s.next must return array{value, done}", TypeOf(m.obj))
+	}
+
+	done, ok := result.Variable("done").(*Bool)
+	if !ok {
+		return nil, false, fmt.Errorf("%s.next must return a boolean 'done' key", TypeOf(m.obj))
+	}
+
+	if done.value {
+		return nil, true, nil
+	}
+
+	value := result.Variable("value")
+	if value == nil {
+		value = NewNil("value", nil)
+	}
+	return value, false, nil
+}
+
+func (l *List) Iterator() Iterator {
+	// the length is fixed when the iteration starts, appended items are not visited
+	i, length := 0, len(l.value)
+	return IteratorFunc(func() (Object, bool, error) {
+		if i >= length || i >= len(l.value) {
+			return nil, true, nil
+		}
+		i++
+		return l.value[i-1], false, nil
+	})
+}
+
+func (s *String) Iterator() Iterator {
+	chars := strings.Split(s.value, "")
+	i := 0
+	return IteratorFunc(func() (Object, bool, error) {
+		if i >= len(chars) {
+			return nil, true, nil
+		}
+		i++
+		return NewString(s.name, chars[i-1], s.debug), false, nil
+	})
+}
+
+func (n *Integer) Iterator() Iterator {
+	i := 0
+	return IteratorFunc(func() (Object, bool, error) {
+		if i >= n.value {
+			return nil, true, nil
+		}
+		i++
+		return NewInteger(n.name, i-1, n.debug), false, nil
+	})
+}
+
+// Iterator iterates over the entries of the array as array{key, value} pairs
+func (a *Array) Iterator() Iterator {
+	keys := append([]Object(nil), a.Keys...)
+	i := 0
+	return IteratorFunc(func() (Object, bool, error) {
+		if i >= len(keys) {
+			return nil, true, nil
+		}
+		key := keys[i]
+		i++
+
+		return NewArray("entry", a.debug, []Object{
+			NewString("key", "key", nil),
+			NewString("key", "value", nil),
+		}, []Object{
+			key,
+			a.Map[key],
+		}), false, nil
+	})
+}
@@ -1,6 +1,7 @@
 package lang
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/flarelang/flare/internal/models"
@@ -19,9 +20,13 @@ const (
 	TNothing    ObjType = "<Object:nothing>"
 	TNil        ObjType = "<Object:nil>"
 	TDefinition ObjType = "<Definition>"
+	TInterface  ObjType = "<Interface>"
+	TEnum       ObjType = "<Enum>"
+	TEnumValue  ObjType = "<Object:enum>"
 	TInstance   ObjType = "<Object:instance>"
 	TFunction   ObjType = "<Function>"
 	TIOStream   ObjType = "<Object:iostream>"
+	TError      ObjType = "<Object:error>"
 	TArray      ObjType = "<Object:array>"
 	TFnRef      ObjType = "<Object:function>"
 	TAddr       ObjType = "<Object:address>"
@@ -112,6 +117,35 @@ type VariadicMethod interface {
 	GetVariadicArg() string
 }
 
+// ContextMethod represents a method that can be executed with the cancellation context of the caller
+type ContextMethod interface {
+	Method
+	// ExecuteContext executes the method with the given context and arguments
+	ExecuteContext(ctx context.Context, args []Object) (Object, error)
+}
+
+// DefaultArgsMethod represents a method with optional arguments in the language
+type DefaultArgsMethod interface {
+	Method
+	// HasDefault returns if the argument has a default value
+	HasDefault(arg string) bool
+	// Default creates the default value of the argument
+	Default(arg string) (Object, error)
+}
+
+// Iterable represents an object that can be iterated over lazily in `for` and `spin` loops
+type Iterable interface {
+	Object
+	// Iterator returns a new iterator over the items of the object
+	Iterator() Iterator
+}
+
+// Iterator returns the items of an iterable one by one
+type Iterator interface {
+	// Next returns the next item, done is true when there are no more items
+	Next() (item Object, done bool, err error)
+}
+
 // Module represents a module in the language
 type Module interface {
 	// Namespace returns the namespace of the module
@@ -64,3 +64,53 @@ func Immute(obj Object) Object {
 	obj.Immute()
 	return obj
 }
+
+// TypeOf returns the type name of the object, preferring a custom type string
+// like the definition name of an instance over the generic object type.
+func TypeOf(obj Object) string {
+	if ts, ok := obj.(interface{ TypeString() string }); ok {
+		return ts.TypeString()
+	}
+
+	return string(obj.Type())
+}
+
+// builtinTypes are the types of the builtin type names in type annotations
+var builtinTypes = map[string]ObjType{
+	"string": TString,
+	"int":    TInt,
+	"float":  TFloat,
+	"bool":   TBool,
+	"list":   TList,
+	"array":  TArray,
+	"fn":     TFnRef,
+	"error":  TError,
+	"nil":    TNil,
+	"any":    TAny,
+}
+
+// TypeByName returns the builtin type of a type annotation, like TInt for `int`
+func TypeByName(name string) (ObjType, bool) {
+	typ, ok := builtinTypes[name]
+	return typ, ok
+}
+
+// TypeName returns the type of the object as it is written in type annotations,
+// like `int` or the definition name of an instance
+func TypeName(obj Object) string {
+	if obj == nil {
+		return "nothing"
+	}
+
+	if ts, ok := obj.(interface{ TypeString() string }); ok {
+		return ts.TypeString()
+	}
+
+	for name, typ := range builtinTypes {
+		if typ == obj.Type() {
+			return name
+		}
+	}
+
+	return string(obj.Type())
+}
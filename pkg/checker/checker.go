@@ -0,0 +1,376 @@
+package checker
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/flarelang/flare/internal/ast"
+	"github.com/flarelang/flare/internal/errs"
+	"github.com/flarelang/flare/internal/lexer"
+	"github.com/flarelang/flare/internal/models"
+	"github.com/flarelang/flare/internal/tokens"
+	"github.com/flarelang/flare/lang"
+)
+
+var (
+	// ErrTypeMismatch is reported when a value can not match the type annotation
+	ErrTypeMismatch = fmt.Errorf("type mismatch")
+	// ErrUnknownType is reported when a type annotation names an unknown type
+	ErrUnknownType = fmt.Errorf("unknown type")
+	// ErrMatchNotExhaustive is reported when a match on an enum value does not cover every value
+	ErrMatchNotExhaustive = fmt.Errorf("match is not exhaustive")
+)
+
+// Checker finds the obvious type errors of a program before running it,
+// the types of the values are inferred from literals, typed variables and typed functions
+type Checker struct {
+	issues []error
+}
+
+// New creates a new checker
+func New() *Checker {
+	return &Checker{}
+}
+
+// CheckPath checks a file or every .fl file of a directory
+func (c *Checker) CheckPath(path string) ([]error, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		return c.CheckFile(path)
+	}
+
+	var issues []error
+	err = filepath.Walk(path, func(file string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() || filepath.Ext(file) != ".fl" {
+			return nil
+		}
+
+		found, err := c.CheckFile(file)
+		if err != nil {
+			return err
+		}
+		issues = append(issues, found...)
+		return nil
+	})
+
+	return issues, err
+}
+
+// CheckFile parses and checks the file, syntax errors are returned as issues
+func (c *Checker) CheckFile(file string) ([]error, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	lx := lexer.New(file)
+	ts, err := lx.Parse(bytes.NewReader(b))
+	if err != nil {
+		return []error{err}, nil
+	}
+
+	nodes, err := ast.NewBuilder().Build(ts)
+	if err != nil {
+		return []error{err}, nil
+	}
+
+	return c.Check(nodes), nil
+}
+
+// Check checks the nodes of a file and returns the found issues
+func (c *Checker) Check(nodes []*models.Node) []error {
+	c.issues = nil
+	c.block(newScope(nil), nodes)
+	return c.issues
+}
+
+func (c *Checker) report(debug *models.Debug, err error, format string, args ...any) {
+	c.issues = append(c.issues, errs.WithDebug(fmt.Errorf("%w: %s", err, fmt.Sprintf(format, args...)), debug))
+}
+
+// block checks the nodes of a block, the functions and definitions of the block can be used before they are declared
+func (c *Checker) block(s *scope, nodes []*models.Node) {
+	for _, n := range nodes {
+		switch n.Type {
+		case tokens.Function:
+			s.funcs[n.Content] = newSignature(n)
+		case tokens.Define:
+			s.declareDefinition(n)
+		case tokens.Interface:
+			s.types[n.Content] = &typeInfo{iface: true}
+		case tokens.Enum:
+			s.declareEnum(n)
+		}
+	}
+
+	for _, n := range nodes {
+		c.node(s, n)
+	}
+}
+
+func (c *Checker) node(s *scope, n *models.Node) {
+	if n == nil {
+		return
+	}
+
+	switch n.Type {
+	case tokens.Use:
+		// selectively imported members can be definitions, they are known but not checked
+		for _, member := range n.Args {
+			alias, _ := member.Value.(string)
+			s.types[alias] = &typeInfo{unknown: true}
+		}
+		return
+	case tokens.Function, tokens.InlineFunction:
+		c.function(s, n)
+		return
+	case tokens.Define:
+		c.block(newScope(s), n.Children)
+		return
+	case tokens.Let, tokens.Const:
+		c.declaration(s, n)
+		return
+	case tokens.Assign:
+		c.assignment(s, n)
+		return
+	case tokens.Return:
+		c.ret(s, n)
+	case tokens.FuncCall:
+		c.call(s, n)
+	case tokens.Match:
+		c.match(s, n)
+	}
+
+	for _, arg := range n.Args {
+		c.node(s, arg)
+	}
+
+	if len(n.Children) > 0 {
+		if n.VariableType == tokens.ExpressionVariable || n.Type == tokens.FuncCall || n.Type == tokens.FuncArg {
+			for _, child := range n.Children {
+				c.node(s, child)
+			}
+		} else {
+			c.block(newScope(s), n.Children)
+		}
+	}
+}
+
+// function checks the body of a function with its typed parameters
+func (c *Checker) function(s *scope, n *models.Node) {
+	fs := newScope(s)
+	fs.function = newSignature(n)
+
+	for _, param := range n.Args {
+		typ := annotation(param, "type")
+		if typ != "" {
+			c.knownType(s, typ, param.Debug)
+		}
+		fs.vars[param.Content] = typ
+
+		for _, child := range param.Children {
+			c.node(s, child)
+		}
+
+		if def := c.declarationValue(s, param); typ != "" && def != "" && !s.compatible(typ, def) {
+			c.report(param.Debug, ErrTypeMismatch, "default value of %s must be %s, got %s", param.Content, typ, def)
+		}
+	}
+
+	if returns := annotation(n, "returns"); returns != "" {
+		c.knownType(s, returns, n.Debug)
+	}
+
+	c.block(fs, n.Children)
+}
+
+func (c *Checker) declaration(s *scope, n *models.Node) {
+	for _, child := range n.Children {
+		c.node(s, child)
+	}
+
+	value := c.declarationValue(s, n)
+	typ := annotation(n, "type")
+
+	if typ == "" {
+		// only constants keep the inferred type, a variable can change its type later
+		if n.Type == tokens.Const {
+			s.vars[n.Content] = value
+		} else {
+			s.vars[n.Content] = ""
+		}
+		return
+	}
+
+	c.knownType(s, typ, n.Debug)
+	s.vars[n.Content] = typ
+
+	// typed variables can hold nil, they start as nil when declared without a value
+	if value != "" && value != "nil" && !s.compatible(typ, value) {
+		c.report(n.Debug, ErrTypeMismatch, "variable %s must be %s, got %s", n.Content, typ, value)
+	}
+}
+
+func (c *Checker) assignment(s *scope, n *models.Node) {
+	for _, child := range n.Children {
+		c.node(s, child)
+	}
+
+	if n.HasFlag("index") {
+		return
+	}
+
+	typ, ok := s.variable(n.Content)
+	if !ok || typ == "" {
+		return
+	}
+
+	if value := c.declarationValue(s, n); value != "" && value != "nil" && !s.compatible(typ, value) {
+		c.report(n.Debug, ErrTypeMismatch, "variable %s must be %s, got %s", n.Content, typ, value)
+	}
+}
+
+func (c *Checker) ret(s *scope, n *models.Node) {
+	fn := s.currentFunction()
+	if fn == nil || fn.returns == "" || len(n.Children) != 1 {
+		return
+	}
+
+	if value := s.infer(n.Children[0]); value != "" && !s.compatible(fn.returns, value) {
+		c.report(n.Debug, ErrTypeMismatch, "return value of %s(...) must be %s, got %s", fn.name, fn.returns, value)
+	}
+}
+
+// call checks the arguments of a call of a typed function or constructor
+func (c *Checker) call(s *scope, n *models.Node) {
+	sig := s.signature(n.Content)
+	if sig == nil {
+		return
+	}
+
+	for i, arg := range n.Args {
+		var param *parameter
+
+		if arg.HasFlag("named") {
+			param = sig.param(arg.Content)
+		} else if i < len(sig.params) {
+			param = &sig.params[i]
+		}
+
+		if param == nil || param.typ == "" {
+			continue
+		}
+
+		value := s.infer(arg)
+		if arg.HasFlag("named") && len(arg.Children) == 1 {
+			value = s.infer(arg.Children[0])
+		}
+
+		if value != "" && !s.compatible(param.typ, value) {
+			debug := arg.Debug
+			if debug == nil {
+				debug = n.Debug
+			}
+			c.report(debug, ErrTypeMismatch, "argument %s of %s(...) must be %s, got %s", param.name, n.Content, param.typ, value)
+		}
+	}
+}
+
+// match checks that a match on a value of an enum covers every value of the enum,
+// arms with guards do not count and an `else` arm or a binding covers every value
+func (c *Checker) match(s *scope, n *models.Node) {
+	if len(n.Args) != 1 {
+		return
+	}
+
+	typ := s.infer(n.Args[0])
+	info := s.typeInfo(typ)
+	if info == nil || info.members == nil {
+		return
+	}
+
+	covered := make(map[string]bool)
+	for _, arm := range n.Children {
+		if len(arm.Args) != 1 {
+			continue
+		}
+
+		pattern := arm.Args[0]
+		switch {
+		case pattern.Type == tokens.Else:
+			return
+		case pattern.Type == tokens.Identifier && pattern.Reference:
+			covered[pattern.Content] = true
+		case pattern.Type == tokens.Identifier:
+			if bound, _ := pattern.Value.(string); bound == "" || bound == typ || bound == "any" {
+				return
+			}
+		}
+	}
+
+	var missing []string
+	for _, member := range info.members {
+		if !covered[typ+"."+member] {
+			missing = append(missing, typ+"."+member)
+		}
+	}
+
+	if len(missing) > 0 {
+		c.report(n.Debug, ErrMatchNotExhaustive, "missing %s", strings.Join(missing, ", "))
+	}
+}
+
+// declarationValue infers the value of a declaration, an assignment or a default value
+func (c *Checker) declarationValue(s *scope, n *models.Node) string {
+	switch n.VariableType {
+	case tokens.ExpressionVariable:
+		if len(n.Children) == 1 {
+			return s.infer(n.Children[0])
+		}
+		return ""
+	case tokens.ReferenceVariable:
+		name, _ := n.Value.(string)
+		typ, _ := s.variable(name)
+		return typ
+	case tokens.NilVariable:
+		if n.Type == tokens.Identifier {
+			return ""
+		}
+		return "nil"
+	}
+
+	return s.infer(n)
+}
+
+// knownType reports the annotations naming a type that is not declared in the file,
+// dotted types come from other namespaces and are not checked
+func (c *Checker) knownType(s *scope, typ string, debug *models.Debug) {
+	if isBuiltin(typ) {
+		return
+	}
+
+	if s.typeInfo(typ) == nil && !isDotted(typ) {
+		c.report(debug, ErrUnknownType, "%s", typ)
+	}
+}
+
+func annotation(n *models.Node, key string) string {
+	typ, _ := n.Map[key].(string)
+	return typ
+}
+
+func isBuiltin(typ string) bool {
+	_, ok := lang.TypeByName(typ)
+	return ok
+}
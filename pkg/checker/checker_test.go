@@ -0,0 +1,188 @@
+package checker
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/flarelang/flare/internal/ast"
+	"github.com/flarelang/flare/internal/lexer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecker_Check(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		// want are the errors of the issues and a part of their message, in the order they are reported
+		want []issue
+	}{
+		{
+			name: "literals match their types",
+			src: `
+				let a: int = 1;
+				let b: float = 1.5;
+				let c: string = "text";
+				let d: bool = true;
+				let e: list = [1, 2];
+				let f: any = "anything";
+				let g: string;
+			`,
+		},
+		{
+			name: "typed variables can be nil",
+			src: `
+				let a: int = nil;
+				a = nil;
+				let b: string;
+				b = "text";
+				b = nil;
+			`,
+		},
+		{
+			name: "literal mismatch",
+			src:  `let a: int = "text";`,
+			want: []issue{{ErrTypeMismatch, "variable a must be int, got string"}},
+		},
+		{
+			name: "typed variables are inferred",
+			src: `
+				let a: int = 1;
+				let b: string = a;
+			`,
+			want: []issue{{ErrTypeMismatch, "variable b must be string, got int"}},
+		},
+		{
+			name: "constants keep the inferred type",
+			src: `
+				const a = "text";
+				let b: int = a;
+			`,
+			want: []issue{{ErrTypeMismatch, "variable b must be int, got string"}},
+		},
+		{
+			name: "untyped variables can change their type",
+			src: `
+				let a = "text";
+				a = 1;
+				let b: int = a;
+			`,
+		},
+		{
+			name: "argument mismatch",
+			src: `
+				fn add(a: int, b: int): int {
+					return a + b;
+				}
+				add(1, 2);
+				add(1, "2");
+				add(b: "2", a: 1);
+			`,
+			want: []issue{
+				{ErrTypeMismatch, "argument b of add(...) must be int, got string"},
+				{ErrTypeMismatch, "argument b of add(...) must be int, got string"},
+			},
+		},
+		{
+			name: "functions can be called before they are declared",
+			src: `
+				greet(1);
+				fn greet(name: string) {}
+			`,
+			want: []issue{{ErrTypeMismatch, "argument name of greet(...) must be string, got int"}},
+		},
+		{
+			name: "default value mismatch",
+			src:  `fn greet(name: string = 1) {}`,
+			want: []issue{{ErrTypeMismatch, "default value of name must be string, got int"}},
+		},
+		{
+			name: "return mismatch",
+			src: `
+				fn name(): string {
+					return 1;
+				}
+			`,
+			want: []issue{{ErrTypeMismatch, "return value of name(...) must be string, got int"}},
+		},
+		{
+			name: "assignment mismatch",
+			src: `
+				let a: int = 1;
+				a = 2;
+				a = "text";
+			`,
+			want: []issue{{ErrTypeMismatch, "variable a must be int, got string"}},
+		},
+		{
+			name: "definitions are types",
+			src: `
+				define User {}
+				define Admin extends User {}
+				define Post {}
+
+				fn greet(user: User) {}
+				greet(User());
+				greet(Admin());
+				greet(Post());
+
+				let admin: Admin = User();
+			`,
+			want: []issue{
+				{ErrTypeMismatch, "argument user of greet(...) must be User, got Post"},
+				{ErrTypeMismatch, "variable admin must be Admin, got User"},
+			},
+		},
+		{
+			name: "unknown types",
+			src: `
+				let a: Unknown;
+				fn f(b: Missing): Nothing {}
+			`,
+			want: []issue{
+				{ErrUnknownType, "Unknown"},
+				{ErrUnknownType, "Missing"},
+				{ErrUnknownType, "Nothing"},
+			},
+		},
+		{
+			name: "dotted and imported types are not checked",
+			src: `
+				use (User) from models;
+				let a: models.Post = 1;
+				let b: User = 1;
+				fn f(c: User, d: models.Post) {}
+				f(1, 2);
+			`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts, err := lexer.New("<test>").Parse(strings.NewReader(tt.src))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			nodes, err := ast.NewBuilder().Build(ts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			issues := New().Check(nodes)
+			if !assert.Len(t, issues, len(tt.want), "issues: %v", issues) {
+				return
+			}
+
+			for i, want := range tt.want {
+				assert.True(t, errors.Is(issues[i], want.err), "issue %d should be %v, got %v", i, want.err, issues[i])
+				assert.Contains(t, issues[i].Error(), want.message)
+			}
+		})
+	}
+}
+
+type issue struct {
+	err     error
+	message string
+}
@@ -0,0 +1,266 @@
+package checker
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/flarelang/flare/internal/models"
+	"github.com/flarelang/flare/internal/tokens"
+)
+
+// scope holds the known types of a block, an empty type means the type is unknown
+type scope struct {
+	parent *scope
+
+	vars  map[string]string
+	funcs map[string]*signature
+	types map[string]*typeInfo
+
+	// function is the signature of the function the scope is the body of
+	function *signature
+}
+
+// typeInfo describes a definition, an interface or an enum declared in the file
+type typeInfo struct {
+	extends     string
+	constructor *signature
+	iface       bool
+	// members are the values of an enum, they are nil for definitions and interfaces
+	members []string
+	// unknown types are imported, nothing is known about them
+	unknown bool
+}
+
+// signature is the typed parameters and the return type of a function
+type signature struct {
+	name    string
+	params  []parameter
+	returns string
+}
+
+type parameter struct {
+	name string
+	typ  string
+}
+
+func newScope(parent *scope) *scope {
+	return &scope{
+		parent: parent,
+		vars:   make(map[string]string),
+		funcs:  make(map[string]*signature),
+		types:  make(map[string]*typeInfo),
+	}
+}
+
+func newSignature(n *models.Node) *signature {
+	sig := &signature{name: n.Content, returns: annotation(n, "returns")}
+
+	for _, arg := range n.Args {
+		if arg.HasFlag("variadic") {
+			continue
+		}
+		sig.params = append(sig.params, parameter{name: arg.Content, typ: annotation(arg, "type")})
+	}
+
+	return sig
+}
+
+func (sig *signature) param(name string) *parameter {
+	for i := range sig.params {
+		if sig.params[i].name == name {
+			return &sig.params[i]
+		}
+	}
+	return nil
+}
+
+// declareDefinition declares the definition as a type, its constructor checks the arguments of `Name(...)`
+func (s *scope) declareDefinition(n *models.Node) {
+	info := &typeInfo{}
+	info.extends, _ = n.Map["extends"].(string)
+
+	for _, child := range n.Children {
+		if child.Type == tokens.Function && child.Content == "construct" {
+			info.constructor = newSignature(child)
+			info.constructor.name = n.Content
+		}
+	}
+
+	s.types[n.Content] = info
+}
+
+// declareEnum declares the enum as a type, `Name.Value` is inferred as a value of it
+func (s *scope) declareEnum(n *models.Node) {
+	info := &typeInfo{members: make([]string, len(n.Args))}
+	for i, member := range n.Args {
+		info.members[i] = member.Content
+	}
+
+	s.types[n.Content] = info
+}
+
+// enumValue returns the enum of a reference like `Role.Admin`, or an empty string
+func (s *scope) enumValue(name string) string {
+	i := strings.LastIndex(name, ".")
+	if i < 0 {
+		return ""
+	}
+
+	enum, member := name[:i], name[i+1:]
+	if _, ok := s.variable(enum); ok {
+		return ""
+	}
+
+	if info := s.typeInfo(enum); info != nil && slices.Contains(info.members, member) {
+		return enum
+	}
+	return ""
+}
+
+func (s *scope) variable(name string) (string, bool) {
+	for sc := s; sc != nil; sc = sc.parent {
+		if typ, ok := sc.vars[name]; ok {
+			return typ, true
+		}
+	}
+	return "", false
+}
+
+func (s *scope) typeInfo(name string) *typeInfo {
+	for sc := s; sc != nil; sc = sc.parent {
+		if info, ok := sc.types[name]; ok {
+			return info
+		}
+	}
+	return nil
+}
+
+// signature returns the signature of the called function or the constructor of the definition
+func (s *scope) signature(name string) *signature {
+	for sc := s; sc != nil; sc = sc.parent {
+		// a variable hides the function with the same name
+		if _, ok := sc.vars[name]; ok {
+			return nil
+		}
+		if sig, ok := sc.funcs[name]; ok {
+			return sig
+		}
+		if info, ok := sc.types[name]; ok {
+			return info.constructor
+		}
+	}
+	return nil
+}
+
+func (s *scope) currentFunction() *signature {
+	for sc := s; sc != nil; sc = sc.parent {
+		if sc.function != nil {
+			return sc.function
+		}
+	}
+	return nil
+}
+
+// infer returns the type of the value of the node, or an empty string if it can not be known before running
+func (s *scope) infer(n *models.Node) string {
+	if n == nil {
+		return ""
+	}
+
+	switch n.VariableType {
+	case tokens.IntVariable:
+		return "int"
+	case tokens.FloatVariable:
+		return "float"
+	case tokens.StringVariable, tokens.TemplateVariable:
+		return "string"
+	case tokens.BoolVariable:
+		return "bool"
+	case tokens.ListVariable:
+		return "list"
+	case tokens.ArrayVariable:
+		return "array"
+	case tokens.FunctionVariable:
+		return "fn"
+	case tokens.InlineValue:
+		return inlineType(n)
+	case tokens.ReferenceVariable:
+		if len(n.ObjectAccessors) > 0 {
+			return ""
+		}
+		if isDotted(n.Content) {
+			return s.enumValue(n.Content)
+		}
+		typ, _ := s.variable(n.Content)
+		return typ
+	case tokens.FunctionCallVariable:
+		if len(n.Children) > 0 || len(n.ObjectAccessors) > 0 {
+			return ""
+		}
+		if _, ok := s.variable(n.Content); ok {
+			return ""
+		}
+		if info := s.typeInfo(n.Content); info != nil && !info.iface && !info.unknown {
+			return n.Content
+		}
+		if sig := s.signature(n.Content); sig != nil {
+			return sig.returns
+		}
+	case tokens.ExpressionVariable:
+		if len(n.Children) == 1 {
+			return s.infer(n.Children[0])
+		}
+	}
+
+	return ""
+}
+
+func inlineType(n *models.Node) string {
+	switch n.Value.(type) {
+	case int:
+		return "int"
+	case float64:
+		return "float"
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	}
+
+	if n.Type == tokens.Nil {
+		return "nil"
+	}
+	return ""
+}
+
+// compatible reports whether a value of the given type can be used as the expected type
+func (s *scope) compatible(expected, got string) bool {
+	if expected == got || expected == "any" || got == "" {
+		return true
+	}
+
+	info := s.typeInfo(expected)
+	if info == nil {
+		// dotted types and unknown names are not checked
+		return isDotted(expected) || !isBuiltin(expected)
+	}
+
+	if info.iface || info.unknown {
+		return true
+	}
+
+	// a child definition can be used as its parent
+	seen := map[string]bool{got: true}
+	for typ := s.typeInfo(got); typ != nil && typ.extends != "" && !seen[typ.extends]; typ = s.typeInfo(typ.extends) {
+		if typ.extends == expected {
+			return true
+		}
+		seen[typ.extends] = true
+	}
+
+	return false
+}
+
+func isDotted(typ string) bool {
+	return strings.Contains(typ, ".")
+}
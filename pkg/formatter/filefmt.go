@@ -85,12 +85,23 @@ func (f *FileFmt) formatNode(scope Scope, indent int, sb *strings.Builder, node
 		}
 		break
 	case tokens.Function:
-		sb.WriteString("fn " + node.Content + "(")
+		f.formatDecorators(sb, node, tab)
+		if node.HasFlag("generator") {
+			sb.WriteString("fn* " + node.Content + "(")
+		} else {
+			sb.WriteString("fn " + node.Content + "(")
+		}
 		for i, param := range node.Args {
 			if i > 0 {
 				sb.WriteString(", ")
 			}
+			if param.HasFlag("variadic") {
+				sb.WriteString("...")
+			}
 			sb.WriteString(param.Content)
+			if param.HasFlag("default") {
+				sb.WriteString(" = " + f.formatValue(param))
+			}
 		}
 		sb.WriteString(") {")
 		if len(node.Children) != 0 {
@@ -119,6 +130,13 @@ func (f *FileFmt) formatNode(scope Scope, indent int, sb *strings.Builder, node
 		}
 		break
 	case tokens.Let:
+		if node.HasFlag("destructure") {
+			sb.WriteString("let " + f.formatPattern(node.Args[0]) + " = ")
+			sb.WriteString(f.formatValue(node, indent))
+			sb.WriteString(";\n")
+			break
+		}
+
 		if node.VariableType == tokens.NilVariable {
 			sb.WriteString("let " + node.Content + ";\n")
 			break
@@ -135,6 +153,13 @@ func (f *FileFmt) formatNode(scope Scope, indent int, sb *strings.Builder, node
 		}
 		break
 	case tokens.Const:
+		if node.HasFlag("destructure") {
+			sb.WriteString("const " + f.formatPattern(node.Args[0]) + " = ")
+			sb.WriteString(f.formatValue(node, indent))
+			sb.WriteString(";\n")
+			break
+		}
+
 		if node.VariableType == tokens.NilVariable {
 			sb.WriteString("const " + node.Content + ";\n")
 			break
@@ -151,7 +176,17 @@ func (f *FileFmt) formatNode(scope Scope, indent int, sb *strings.Builder, node
 		}
 		break
 	case tokens.Assign:
-		sb.WriteString(node.Content + " = ")
+		sb.WriteString(node.Content)
+		if node.HasFlag("index") {
+			for _, accessor := range node.Args {
+				sb.WriteString("[" + f.formatAccessor(accessor) + "]")
+			}
+		}
+		if operator, ok := node.Map["operator"].(string); ok {
+			sb.WriteString(" " + operator + " ")
+		} else {
+			sb.WriteString(" = ")
+		}
 		sb.WriteString(f.formatValue(node))
 		sb.WriteString(";\n")
 
@@ -161,8 +196,55 @@ func (f *FileFmt) formatNode(scope Scope, indent int, sb *strings.Builder, node
 			}
 		}
 		break
+	case tokens.Interface:
+		sb.WriteString("interface " + node.Content + " {\n")
+		for _, member := range node.Children {
+			sb.WriteString(tab + "\t")
+			if member.Type == tokens.Let {
+				sb.WriteString("let " + member.Content + ";\n")
+				continue
+			}
+
+			params := make([]string, len(member.Args))
+			for i, param := range member.Args {
+				params[i] = param.Content
+				if param.HasFlag("variadic") {
+					params[i] = "..." + param.Content
+				}
+			}
+			sb.WriteString("fn " + member.Content + "(" + strings.Join(params, ", ") + ");\n")
+		}
+		sb.WriteString(tab + "}\n")
+
+		if next != nil {
+			sb.WriteRune('\n')
+		}
+		break
+	case tokens.Enum:
+		sb.WriteString("enum " + node.Content + " {\n")
+		for _, member := range node.Args {
+			sb.WriteString(tab + "\t" + member.Content)
+			if member.VariableType != tokens.NilVariable {
+				sb.WriteString(" = " + f.formatValue(member))
+			}
+			sb.WriteString(",\n")
+		}
+		sb.WriteString(tab + "}\n")
+
+		if next != nil {
+			sb.WriteRune('\n')
+		}
+		break
 	case tokens.Define:
-		sb.WriteString("define " + node.Content + " {")
+		f.formatDecorators(sb, node, tab)
+		sb.WriteString("define " + node.Content)
+		if parent, ok := node.Map["extends"].(string); ok {
+			sb.WriteString(" extends " + parent)
+		}
+		if interfaces, ok := node.Map["implements"].([]string); ok {
+			sb.WriteString(" implements " + strings.Join(interfaces, ", "))
+		}
+		sb.WriteString(" {")
 		if len(node.Children) != 0 {
 			sb.WriteString("\n")
 		}
@@ -188,7 +270,45 @@ func (f *FileFmt) formatNode(scope Scope, indent int, sb *strings.Builder, node
 			sb.WriteRune('\n')
 		}
 		break
-	case tokens.Addition, tokens.Subtraction, tokens.Multiplication, tokens.Division, tokens.Equation, tokens.NotEquation, tokens.Greater, tokens.GreaterOrEqual, tokens.Less, tokens.LessOrEqual, tokens.And, tokens.Or, tokens.Not, tokens.Power:
+	case tokens.Break, tokens.Continue:
+		sb.WriteString(node.Content + ";\n")
+		break
+	case tokens.Yield:
+		sb.WriteString("yield " + f.formatValue(node, indent) + ";\n")
+		break
+	case tokens.Throw:
+		sb.WriteString("throw " + f.formatValue(node, indent) + ";\n")
+		break
+	case tokens.Defer:
+		sb.WriteString("defer " + f.formatValue(node, indent) + ";\n")
+		break
+	case tokens.Try:
+		sb.WriteString("try ")
+		if err := f.formatBlock(scope, indent, sb, node.Children); err != nil {
+			return err
+		}
+
+		for _, clause := range node.Args {
+			switch {
+			case clause.Type == tokens.Finally:
+				sb.WriteString(" finally ")
+			case clause.Content == "":
+				sb.WriteString(" catch ")
+			default:
+				sb.WriteString(" catch (" + clause.Content)
+				if typ, ok := clause.Value.(string); ok {
+					sb.WriteString(": " + typ)
+				}
+				sb.WriteString(") ")
+			}
+
+			if err := f.formatBlock(scope, indent, sb, clause.Children); err != nil {
+				return err
+			}
+		}
+		sb.WriteString("\n")
+		break
+	case tokens.Addition, tokens.Subtraction, tokens.Multiplication, tokens.Division, tokens.Equation, tokens.NotEquation, tokens.Greater, tokens.GreaterOrEqual, tokens.Less, tokens.LessOrEqual, tokens.And, tokens.Or, tokens.Not, tokens.Power, tokens.Pipe, tokens.Question, tokens.Colon, tokens.Coalesce:
 		sb.WriteString(node.Content)
 		break
 	case tokens.String, tokens.Number, tokens.Bool:
@@ -207,6 +327,9 @@ func (f *FileFmt) formatNode(scope Scope, indent int, sb *strings.Builder, node
 			if i > 0 {
 				sb.WriteString(", ")
 			}
+			if arg.HasFlag("named") {
+				sb.WriteString(arg.Content + ": ")
+			}
 			sb.WriteString(f.formatValue(arg))
 		}
 		sb.WriteString(");\n")
@@ -280,6 +403,97 @@ func (f *FileFmt) formatNode(scope Scope, indent int, sb *strings.Builder, node
 	return nil
 }
 
+// formatDecorators writes the decorators of a declaration, one per line
+func (f *FileFmt) formatDecorators(sb *strings.Builder, node *models.Node, tab string) {
+	decorators, _ := node.Map["decorators"].([]*models.Node)
+	for _, decorator := range decorators {
+		sb.WriteString("@" + decorator.Content)
+		if decorator.Type == tokens.FuncCall {
+			sb.WriteString("(")
+			for i, arg := range decorator.Args {
+				if i > 0 {
+					sb.WriteString(", ")
+				}
+				sb.WriteString(f.formatValue(arg))
+			}
+			sb.WriteString(")")
+		}
+		sb.WriteString("\n" + tab)
+	}
+}
+
+// formatBlock formats the statements of a block between braces
+func (f *FileFmt) formatBlock(scope Scope, indent int, sb *strings.Builder, nodes []*models.Node) error {
+	sb.WriteString("{")
+	if len(nodes) == 0 {
+		sb.WriteString("}")
+		return nil
+	}
+	sb.WriteString("\n")
+
+	for i, stmt := range nodes {
+		var next *models.Node
+		if i < len(nodes)-1 {
+			next = nodes[i+1]
+		}
+
+		if err := f.formatNode(scope, indent+1, sb, stmt, next); err != nil {
+			return err
+		}
+	}
+
+	sb.WriteString(strings.Repeat("\t", indent) + "}")
+	return nil
+}
+
+// formatPattern formats a destructuring or match pattern
+func (f *FileFmt) formatPattern(node *models.Node) string {
+	switch node.Type {
+	case tokens.Else:
+		return "else"
+	case tokens.List:
+		parts := make([]string, len(node.Children))
+		for i, child := range node.Children {
+			if child.HasFlag("rest") {
+				parts[i] = "..." + child.Content
+				continue
+			}
+			parts[i] = f.formatPattern(child)
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	case tokens.Array:
+		parts := make([]string, len(node.Children))
+		for i, child := range node.Children {
+			value := child.Children[0]
+			if value.Type == tokens.Identifier && value.Content == child.Content && value.Value == nil && !value.Reference {
+				parts[i] = child.Content
+				continue
+			}
+			parts[i] = child.Content + ": " + f.formatPattern(value)
+		}
+		return "{" + strings.Join(parts, ", ") + "}"
+	case tokens.Identifier:
+		if typ, ok := node.Value.(string); ok {
+			return node.Content + ": " + typ
+		}
+		return node.Content
+	}
+
+	return f.formatValue(node)
+}
+
+func (f *FileFmt) formatAccessor(node *models.Node) string {
+	if node.Reference {
+		return node.Content
+	}
+
+	if node.Type == tokens.String {
+		return fmt.Sprintf("%q", node.Value)
+	}
+
+	return f.formatValue(node)
+}
+
 func (f *FileFmt) formatValue(node *models.Node, indentArgs ...int) string {
 	indent := 0
 	if len(indentArgs) > 0 {
@@ -10,6 +10,7 @@ import (
 	"github.com/flarelang/flare/internal/cache"
 	"github.com/flarelang/flare/internal/lexer"
 	"github.com/flarelang/flare/internal/models"
+	"github.com/flarelang/flare/internal/race"
 	"github.com/flarelang/flare/internal/runtimev2"
 	"github.com/flarelang/flare/internal/state"
 	"github.com/flarelang/flare/lang"
@@ -21,6 +22,10 @@ type Interpreter struct {
 	mode InterpreterMode
 	// cache is the cache flag
 	cache bool
+	// race is the race detector, nil if race detection is disabled
+	race *race.Detector
+	// searchPaths are the directories of shared imports
+	searchPaths []string
 }
 
 // NewInterpreter creates a new interpreter
@@ -31,6 +36,18 @@ func NewInterpreter(mode InterpreterMode, cache bool) *Interpreter {
 	}
 }
 
+// WithRaceDetector runs the programs with race detection, the found races are collected by the detector
+func (ir *Interpreter) WithRaceDetector(d *race.Detector) *Interpreter {
+	ir.race = d
+	return ir
+}
+
+// WithSearchPaths adds directories where imports are looked up
+func (ir *Interpreter) WithSearchPaths(paths ...string) *Interpreter {
+	ir.searchPaths = append(ir.searchPaths, paths...)
+	return ir
+}
+
 // Interpret interprets the given data
 func (ir *Interpreter) Interpret(fileName string, data io.Reader) (lang.Object, error) {
 	if !strings.HasSuffix(fileName, ".fl") && !strings.HasSuffix(fileName, ".flb") && !strings.HasSuffix(fileName, ".flare") {
@@ -48,7 +65,13 @@ func (ir *Interpreter) Interpret(fileName string, data io.Reader) (lang.Object,
 		return nil, err
 	}
 
-	return run.Execute(nodes)
+	if ir.race != nil {
+		run.WithRaceDetector(ir.race)
+	}
+
+	run.WithSearchPaths(ir.searchPaths...)
+
+	return run.ExecuteFile(fileName, nodes)
 }
 
 // GetNodes gets the nodes from the given data
@@ -29,6 +29,9 @@ type PackageManager struct {
 
 	Packages []*Package `yaml:"packages"`
 
+	// Paths are the directories of shared libraries, imports are looked up in them
+	Paths []string `yaml:"paths,omitempty"`
+
 	root        string
 	packageFile string
 }
@@ -193,6 +196,19 @@ func (pm *PackageManager) save() error {
 	return nil
 }
 
+// SearchPaths returns the paths of the package file, relative paths are resolved from the package root
+func (pm *PackageManager) SearchPaths() []string {
+	paths := make([]string, len(pm.Paths))
+	for i, path := range pm.Paths {
+		if filepath.IsAbs(path) {
+			paths[i] = path
+			continue
+		}
+		paths[i] = filepath.Join(pm.root, path)
+	}
+	return paths
+}
+
 func (pm *PackageManager) Save() error {
 	return pm.save()
 }
@@ -74,7 +74,10 @@ func (p *PrettyCode) highlightToken(mode Mode, token *models.Token, next *models
 		tokens.Function, tokens.Define, tokens.Return,
 		tokens.Namespace, tokens.Use, tokens.As, tokens.From,
 		tokens.While, tokens.For, tokens.Spin,
-		tokens.If, tokens.Else, tokens.In, tokens.Array, tokens.Error:
+		tokens.If, tokens.Else, tokens.In, tokens.Array, tokens.Error,
+		tokens.Break, tokens.Continue, tokens.Match,
+		tokens.Extends, tokens.Implements, tokens.Interface, tokens.Enum, tokens.Yield,
+		tokens.Try, tokens.Catch, tokens.Finally, tokens.Throw, tokens.Defer:
 		return p.highlightKeyword(mode, token.Value)
 	case tokens.Identifier:
 		if next != nil && next.Type == tokens.LeftParenthesis {